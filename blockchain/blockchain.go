@@ -4,15 +4,26 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/core/state"
 	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/encoder"
+	"github.com/NethermindEth/juno/metrics"
 	"github.com/NethermindEth/juno/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+var storeDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "blockchain",
+	Name:      "store_duration_seconds",
+	Help:      "Time taken to check a block against the head and store it along with its state update.",
+})
+
 type ErrIncompatibleBlockAndStateUpdate struct {
 	reason string
 }
@@ -29,20 +40,51 @@ func (e ErrIncompatibleBlock) Error() string {
 	return fmt.Sprintf("incompatible block: %v", e.reason)
 }
 
-// BlockDbKey appends hash to block number to create a db key.
-// Todo: make private after Blockchain.BlockByNumber & BlockchainBlockByHash are implemented.
-type BlockDbKey struct {
+// maxBatchWrites bounds the number of keys written by a single transaction when many blocks are
+// updated at once, so that the transaction does not grow past what the database can commit.
+var maxBatchWrites = 10_000
+
+// ErrRevertingL1Block is returned when reverting a block accepted on L1, which is final.
+var ErrRevertingL1Block = errors.New("cannot revert a block accepted on L1")
+
+// ErrBlockNotFound is returned when the requested block is not in the blockchain.
+type ErrBlockNotFound struct {
+	Number *uint64
+	Hash   *felt.Felt
+}
+
+func (e ErrBlockNotFound) Error() string {
+	if e.Hash != nil {
+		return fmt.Sprintf("block not found: hash %v", e.Hash.Text(16))
+	}
+	return fmt.Sprintf("block not found: number %d", *e.Number)
+}
+
+// ErrTransactionNotFound is returned when the requested transaction is not in the blockchain.
+type ErrTransactionNotFound struct {
+	Hash        *felt.Felt
+	BlockNumber uint64
+	Index       uint64
+}
+
+func (e ErrTransactionNotFound) Error() string {
+	if e.Hash != nil {
+		return fmt.Sprintf("transaction not found: hash %v", e.Hash.Text(16))
+	}
+	return fmt.Sprintf("transaction not found: block number %d index %d", e.BlockNumber, e.Index)
+}
+
+// blockDbKey appends hash to block number to create a db key.
+type blockDbKey struct {
 	Number uint64
 	Hash   *felt.Felt
 }
 
-func (k *BlockDbKey) MarshalBinary() ([]byte, error) {
-	var numB [8]byte
-	binary.BigEndian.PutUint64(numB[:], k.Number)
-	return db.Blocks.Key(numB[:], k.Hash.Marshal()), nil
+func (k *blockDbKey) MarshalBinary() ([]byte, error) {
+	return db.Blocks.Key(encoder.Uint64Bytes(k.Number), k.Hash.Marshal()), nil
 }
 
-func (k *BlockDbKey) UnmarshalBinary(data []byte) error {
+func (k *blockDbKey) UnmarshalBinary(data []byte) error {
 	if len(data) != 41 {
 		return errors.New("key should be 41 bytes long")
 	}
@@ -60,25 +102,29 @@ func (k *BlockDbKey) UnmarshalBinary(data []byte) error {
 type Blockchain struct {
 	network  utils.Network
 	database db.DB
+	log      utils.Logger
 }
 
-func NewBlockchain(database db.DB, network utils.Network) *Blockchain {
+func NewBlockchain(database db.DB, network utils.Network, log utils.Logger) *Blockchain {
 	return &Blockchain{
 		database: database,
 		network:  network,
+		log:      log,
 	}
 }
 
 // Height returns the latest block height. If blockchain is empty nil is returned.
 func (b *Blockchain) Height() *uint64 {
-	headBlock, err := b.Head()
-	if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
+	txn := b.database.NewTransaction(false)
+	defer txn.Discard()
+	height, err := headNumber(txn)
+	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil
+		}
 		panic(fmt.Sprintf("failed to retrieved head block: %v", err))
 	}
-	if headBlock != nil {
-		return &headBlock.Number
-	}
-	return nil
+	return &height
 }
 
 func (b *Blockchain) Head() (*core.Block, error) {
@@ -88,25 +134,239 @@ func (b *Blockchain) Head() (*core.Block, error) {
 }
 
 func (b *Blockchain) head(txn db.Transaction) (*core.Block, error) {
-	headBlockBin, err := txn.Get(db.HeadBlock.Key())
+	number, err := headNumber(txn)
+	if err != nil {
+		return nil, err
+	}
+	return blockByNumber(txn, number)
+}
+
+// headNumber gets the number of the head block, which is all the HeadBlock bucket stores.
+func headNumber(txn db.Transaction) (uint64, error) {
+	numberBinary, err := txn.Get(db.HeadBlock.Key())
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(numberBinary), nil
+}
+
+// BlockByNumber gets the block for a given block number from the database.
+// If there is no such block, [ErrBlockNotFound] is returned.
+func (b *Blockchain) BlockByNumber(number uint64) (*core.Block, error) {
+	var block *core.Block
+	return block, b.database.View(func(txn db.Transaction) error {
+		var err error
+		block, err = blockByNumber(txn, number)
+		return err
+	})
+}
+
+func blockByNumber(txn db.Transaction, number uint64) (*core.Block, error) {
+	hashBinary, err := txn.Get(db.BlockHashesByNumber.Key(encoder.Uint64Bytes(number)))
 	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil, &ErrBlockNotFound{Number: &number}
+		}
 		return nil, err
 	}
+	return blockByKey(txn, &blockDbKey{Number: number, Hash: new(felt.Felt).SetBytes(hashBinary)})
+}
+
+// BlockByHash gets the block for a given block hash from the database.
+// If there is no such block, [ErrBlockNotFound] is returned.
+func (b *Blockchain) BlockByHash(hash *felt.Felt) (*core.Block, error) {
+	var block *core.Block
+	return block, b.database.View(func(txn db.Transaction) error {
+		numberBinary, err := txn.Get(db.BlockNumbersByHash.Key(hash.Marshal()))
+		if err != nil {
+			if errors.Is(err, db.ErrKeyNotFound) {
+				return &ErrBlockNotFound{Hash: hash}
+			}
+			return err
+		}
 
-	headBlock := new(core.Block)
-	if err = encoder.Unmarshal(headBlockBin, headBlock); err != nil {
+		block, err = blockByKey(txn, &blockDbKey{Number: binary.BigEndian.Uint64(numberBinary), Hash: hash})
+		return err
+	})
+}
+
+func blockByKey(txn db.Transaction, key *blockDbKey) (*core.Block, error) {
+	bKey, err := key.MarshalBinary()
+	if err != nil {
 		return nil, err
 	}
-	return headBlock, nil
+
+	blockBinary, err := txn.Get(bKey)
+	if err != nil {
+		return nil, err
+	}
+
+	block := new(core.Block)
+	if err = encoder.Unmarshal(blockBinary, block); err != nil {
+		return nil, err
+	}
+	block.Status, err = blockStatus(txn, block.Number)
+	return block, err
+}
+
+// StateUpdateByNumber gets the state update for a given block number from the database.
+// If there is no such block, [ErrBlockNotFound] is returned.
+func (b *Blockchain) StateUpdateByNumber(number uint64) (*core.StateUpdate, error) {
+	var update *core.StateUpdate
+	return update, b.database.View(func(txn db.Transaction) error {
+		var err error
+		update, err = stateUpdateByNumber(txn, number)
+		return err
+	})
+}
+
+func stateUpdateByNumber(txn db.Transaction, number uint64) (*core.StateUpdate, error) {
+	updateBinary, err := txn.Get(db.StateUpdates.Key(encoder.Uint64Bytes(number)))
+	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil, &ErrBlockNotFound{Number: &number}
+		}
+		return nil, err
+	}
+
+	update := new(core.StateUpdate)
+	return update, encoder.Unmarshal(updateBinary, update)
+}
+
+// ContractStorageAt returns the value of a storage slot of the contract at addr as of the block
+// with the given number. Zero is returned for slots that were not set. If there is no such block,
+// [ErrBlockNotFound] is returned and if the contract was not deployed as of that block,
+// [db.ErrKeyNotFound] is returned.
+func (b *Blockchain) ContractStorageAt(addr, key *felt.Felt, number uint64) (*felt.Felt, error) {
+	var value *felt.Felt
+	return value, b.database.View(func(txn db.Transaction) error {
+		if _, err := txn.Get(db.BlockHashesByNumber.Key(encoder.Uint64Bytes(number))); err != nil {
+			if errors.Is(err, db.ErrKeyNotFound) {
+				return &ErrBlockNotFound{Number: &number}
+			}
+			return err
+		}
+
+		historicalState := state.NewStateAt(txn, number)
+		if _, err := historicalState.GetContractClass(addr); err != nil {
+			return err
+		}
+
+		var err error
+		value, err = historicalState.GetContractStorage(addr, key)
+		return err
+	})
+}
+
+// PendingContractStorage gets the value of the storage slot at key of the contract at addr in the
+// state of the pending block with the given state update, which must be a child of the head.
+// If the contract is not deployed, db.ErrKeyNotFound is returned.
+func (b *Blockchain) PendingContractStorage(pending *core.StateUpdate, addr, key *felt.Felt) (*felt.Felt, error) {
+	var value *felt.Felt
+	return value, b.database.View(func(txn db.Transaction) error {
+		pendingState := state.NewPendingState(pending.StateDiff, state.NewState(txn))
+		if _, err := pendingState.GetContractClass(addr); err != nil {
+			return err
+		}
+
+		var err error
+		value, err = pendingState.GetContractStorage(addr, key)
+		return err
+	})
+}
+
+// TransactionByBlockNumberAndIndex gets the transaction at the given index of the block
+// with the given number. If there is no such block, [ErrBlockNotFound] is returned and
+// if the block has no transaction at index, [ErrTransactionNotFound] is returned.
+func (b *Blockchain) TransactionByBlockNumberAndIndex(number, index uint64) (core.Transaction, error) {
+	var transaction core.Transaction
+	return transaction, b.database.View(func(txn db.Transaction) error {
+		block, err := blockByNumber(txn, number)
+		if err != nil {
+			return err
+		}
+
+		if index >= uint64(len(block.Transactions)) || block.Transactions[index] == nil {
+			return &ErrTransactionNotFound{BlockNumber: number, Index: index}
+		}
+		transaction = block.Transactions[index]
+		return nil
+	})
+}
+
+// TransactionByHash gets the transaction with the given hash from the database.
+// If there is no such transaction, [ErrTransactionNotFound] is returned.
+func (b *Blockchain) TransactionByHash(hash *felt.Felt) (core.Transaction, error) {
+	var transaction core.Transaction
+	return transaction, b.database.View(func(txn db.Transaction) error {
+		block, index, err := blockAndIndexByTxHash(txn, hash)
+		if err != nil {
+			return err
+		}
+
+		if index >= uint64(len(block.Transactions)) || block.Transactions[index] == nil {
+			return &ErrTransactionNotFound{Hash: hash}
+		}
+		transaction = block.Transactions[index]
+		return nil
+	})
+}
+
+// ReceiptByHash gets the receipt of the transaction with the given hash from the database.
+// If there is no such transaction, [ErrTransactionNotFound] is returned.
+func (b *Blockchain) ReceiptByHash(hash *felt.Felt) (*core.TransactionReceipt, error) {
+	var receipt *core.TransactionReceipt
+	return receipt, b.database.View(func(txn db.Transaction) error {
+		block, index, err := blockAndIndexByTxHash(txn, hash)
+		if err != nil {
+			return err
+		}
+
+		if index >= uint64(len(block.Receipts)) {
+			return &ErrTransactionNotFound{Hash: hash}
+		}
+		receipt = block.Receipts[index]
+		return nil
+	})
+}
+
+// blockAndIndexByTxHash returns the block that includes the transaction with the given
+// hash, along with the index of the transaction in that block.
+func blockAndIndexByTxHash(txn db.Transaction, hash *felt.Felt) (*core.Block, uint64, error) {
+	val, err := txn.Get(db.TransactionBlockNumbersAndIndicesByHash.Key(hash.Marshal()))
+	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil, 0, &ErrTransactionNotFound{Hash: hash}
+		}
+		return nil, 0, err
+	}
+
+	number, index := binary.BigEndian.Uint64(val[:8]), binary.BigEndian.Uint64(val[8:])
+	block, err := blockByNumber(txn, number)
+	if err != nil {
+		return nil, 0, err
+	}
+	return block, index, nil
 }
 
 // Store takes a block and state update and performs sanity checks before putting in the database.
 func (b *Blockchain) Store(block *core.Block, stateUpdate *core.StateUpdate) error {
-	return b.database.Update(func(txn db.Transaction) error {
+	if err := b.VerifyBlockHash(block); err != nil {
+		return err
+	}
+	return b.StoreVerified(block, stateUpdate)
+}
+
+// StoreVerified is like [Blockchain.Store] for blocks whose hash has already been checked with
+// [Blockchain.VerifyBlockHash], so that hashing does not hold up the database transaction.
+// The block is still checked against the head and the state update.
+func (b *Blockchain) StoreVerified(block *core.Block, stateUpdate *core.StateUpdate) error {
+	start := time.Now()
+	err := b.database.Update(func(txn db.Transaction) error {
 		if err := b.verifyBlock(txn, block, stateUpdate); err != nil {
 			return err
 		}
-		key := &BlockDbKey{block.Number, block.Hash}
+		key := &blockDbKey{block.Number, block.Hash}
 		bKey, err := key.MarshalBinary()
 		if err != nil {
 			return err
@@ -117,34 +377,154 @@ func (b *Blockchain) Store(block *core.Block, stateUpdate *core.StateUpdate) err
 			return err
 		}
 
-		if err = state.NewState(txn).Update(stateUpdate); err != nil {
+		if err = state.NewState(txn).Update(block.Number, stateUpdate); err != nil {
+			return err
+		}
+
+		stateUpdateBinary, err := encoder.Marshal(stateUpdate)
+		if err != nil {
+			return err
+		}
+		numberBinary := encoder.Uint64Bytes(block.Number)
+		if err = txn.Set(db.StateUpdates.Key(numberBinary), stateUpdateBinary); err != nil {
 			return err
 		}
 
-		if err = txn.Set(db.HeadBlock.Key(), blockBinary); err != nil {
+		if err = txn.Set(db.BlockStatuses.Key(numberBinary), []byte{byte(block.Status)}); err != nil {
+			return err
+		}
+		if err = indexBlock(txn, block); err != nil {
+			return err
+		}
+
+		if err = txn.Set(db.HeadBlock.Key(), numberBinary); err != nil {
 			return err
 		}
 		return txn.Set(bKey, blockBinary)
 	})
+	duration := time.Since(start)
+	storeDuration.Observe(duration.Seconds())
+	if err != nil {
+		return err
+	}
+
+	b.log.Debugw("Committed block", "number", block.Number, "hash", block.Hash.Text(16),
+		"duration", duration)
+	return nil
+}
+
+// indexBlock indexes block by number and hash, its transactions by hash and the contracts which
+// emitted events in it.
+func indexBlock(txn db.Transaction, block *core.Block) error {
+	numberBinary := encoder.Uint64Bytes(block.Number)
+	if err := txn.Set(db.BlockHashesByNumber.Key(numberBinary), block.Hash.Marshal()); err != nil {
+		return err
+	}
+	if err := txn.Set(db.BlockNumbersByHash.Key(block.Hash.Marshal()), numberBinary); err != nil {
+		return err
+	}
+
+	for i, receipt := range block.Receipts {
+		location := append(encoder.Uint64Bytes(block.Number), encoder.Uint64Bytes(uint64(i))...)
+		if err := txn.Set(db.TransactionBlockNumbersAndIndicesByHash.Key(receipt.TransactionHash.Marshal()),
+			location); err != nil {
+			return err
+		}
+
+		for _, event := range receipt.Events {
+			if err := txn.Set(db.ContractEventBlocks.Key(event.From.Marshal(), numberBinary), []byte{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RevertHead removes the head block from the blockchain and undoes its state update,
+// making its parent the new head. It is used to handle chain reorganisations, which cannot
+// replace blocks accepted on L1: [ErrRevertingL1Block] is returned if the head is one of them.
+func (b *Blockchain) RevertHead() error {
+	var head *core.Block
+	err := b.database.Update(func(txn db.Transaction) error {
+		var err error
+		head, err = b.head(txn)
+		if err != nil {
+			return err
+		}
+		numberBinary := encoder.Uint64Bytes(head.Number)
+
+		// blocks accepted on L1 are final
+		if l1Head, l1Err := l1Head(txn); l1Err == nil && head.Number <= l1Head.BlockNumber {
+			return ErrRevertingL1Block
+		} else if l1Err != nil && !errors.Is(l1Err, db.ErrKeyNotFound) {
+			return l1Err
+		}
+
+		if err = state.NewState(txn).Revert(head.Number); err != nil {
+			return err
+		}
+
+		bKey, err := (&blockDbKey{head.Number, head.Hash}).MarshalBinary()
+		if err != nil {
+			return err
+		}
+		keys := [][]byte{
+			bKey,
+			db.StateUpdates.Key(numberBinary),
+			db.BlockHashesByNumber.Key(numberBinary),
+			db.BlockNumbersByHash.Key(head.Hash.Marshal()),
+			db.BlockStatuses.Key(numberBinary),
+		}
+		for _, receipt := range head.Receipts {
+			keys = append(keys, db.TransactionBlockNumbersAndIndicesByHash.Key(receipt.TransactionHash.Marshal()))
+			for _, event := range receipt.Events {
+				keys = append(keys, db.ContractEventBlocks.Key(event.From.Marshal(), numberBinary))
+			}
+		}
+		for _, key := range keys {
+			if err = txn.Delete(key); err != nil {
+				return err
+			}
+		}
+
+		if head.Number == 0 {
+			return txn.Delete(db.HeadBlock.Key())
+		}
+		return txn.Set(db.HeadBlock.Key(), encoder.Uint64Bytes(head.Number-1))
+	})
+	if err != nil {
+		return err
+	}
+
+	b.log.Infow("Reverted head", "number", head.Number, "hash", head.Hash.Text(16))
+	return nil
 }
 
 func (b *Blockchain) VerifyBlock(block *core.Block, stateUpdate *core.StateUpdate) error {
 	txn := b.database.NewTransaction(false)
 	defer txn.Discard()
-	return b.verifyBlock(txn, block, stateUpdate)
+	if err := b.verifyBlock(txn, block, stateUpdate); err != nil {
+		return err
+	}
+	return b.VerifyBlockHash(block)
 }
 
+// verifyBlock checks that block is a child of the head and matches stateUpdate. Its hash is
+// checked separately by [Blockchain.VerifyBlockHash].
 func (b *Blockchain) verifyBlock(txn db.Transaction, block *core.Block,
 	stateUpdate *core.StateUpdate,
 ) error {
 	/*
-		Todo: Transaction and TransactionReceipts
-			- When Block is changed to include a list of Transaction and TransactionReceipts
-			- Further checks would need to be added to ensure Transaction Hash has been computed
-				properly.
-			- Sanity check would need to include checks which ensure there is same number of
-				Transactions and TransactionReceipts.
+		Todo: Further checks would need to be added to ensure Transaction Hash has been computed
+			properly. The hashes of deploy and version 0 invoke transactions from older mainnet
+			blocks do not match the ones computed by core yet.
 	*/
+	if len(block.Transactions) != len(block.Receipts) {
+		return &ErrIncompatibleBlock{
+			"number of transactions and transaction receipts do not match",
+		}
+	}
+
 	head, err := b.head(txn)
 	if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
 		return err
@@ -182,7 +562,13 @@ func (b *Blockchain) verifyBlock(txn db.Transaction, block *core.Block,
 			"block's GlobalStateRoot does not match state update's NewRoot",
 		}
 	}
+	return nil
+}
 
+// VerifyBlockHash checks that the hash of block matches its contents. Blocks whose hash cannot
+// be verified, see [core.ErrUnverifiableBlock], are accepted. Since it does not access the database,
+// it can be used to verify blocks concurrently before they are passed to [Blockchain.StoreVerified].
+func (b *Blockchain) VerifyBlockHash(block *core.Block) error {
 	h, err := core.BlockHash(block, b.network)
 	if err != nil && !errors.As(err, new(*core.ErrUnverifiableBlock)) {
 		return err
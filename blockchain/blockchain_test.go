@@ -1,63 +1,35 @@
 package blockchain
 
 import (
-	_ "embed"
-	"encoding/json"
 	"fmt"
 	"testing"
 
-	"github.com/NethermindEth/juno/clients"
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/core/state"
 	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/encoder"
-	"github.com/NethermindEth/juno/starknetdata/gateway"
+	"github.com/NethermindEth/juno/starknetdata/gateway/gatewaytest"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-var (
-	//go:embed testdata/mainnet_block_0.json
-	mainnetBlock0 []byte
-	//go:embed testdata/mainnet_state_update_0.json
-	mainnetStateUpdate0 []byte
-	//go:embed testdata/mainnet_block_1.json
-	mainnetBlock1 []byte
-	//go:embed testdata/mainnet_state_update_1.json
-	mainnetStateUpdate1 []byte
-)
-
 func TestNewBlockchain(t *testing.T) {
 	t.Run("empty blockchain's head is nil", func(t *testing.T) {
-		chain := NewBlockchain(db.NewTestDb(), utils.MAINNET)
+		chain := NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
 		assert.Equal(t, utils.MAINNET, chain.network)
 		b, err := chain.Head()
 		assert.Nil(t, b)
 		assert.EqualError(t, err, db.ErrKeyNotFound.Error())
 	})
 	t.Run("non-empty blockchain gets head from db", func(t *testing.T) {
-		clientBlock0, clientStateUpdate0 := new(clients.Block), new(clients.StateUpdate)
-		if err := json.Unmarshal(mainnetBlock0, clientBlock0); err != nil {
-			t.Fatal(err)
-		}
-		if err := json.Unmarshal(mainnetStateUpdate0, clientStateUpdate0); err != nil {
-			t.Fatal(err)
-		}
-		block0, err := gateway.AdaptBlock(clientBlock0)
-		if err != nil {
-			t.Fatal(err)
-		}
-		stateUpdate0, err := gateway.AdaptStateUpdate(clientStateUpdate0)
-		if err != nil {
-			t.Fatal(err)
-		}
+		block0, stateUpdate0 := gatewaytest.MainnetBlock(t, 0)
 		testDB := db.NewTestDb()
-		chain := NewBlockchain(testDB, utils.MAINNET)
+		chain := NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
 		assert.NoError(t, chain.Store(block0, stateUpdate0))
 
-		chain = NewBlockchain(testDB, utils.MAINNET)
+		chain = NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
 		b, err := chain.Head()
 		assert.NoError(t, err)
 		assert.Equal(t, block0, b)
@@ -66,30 +38,16 @@ func TestNewBlockchain(t *testing.T) {
 
 func TestHeight(t *testing.T) {
 	t.Run("return nil if blockchain is empty", func(t *testing.T) {
-		chain := NewBlockchain(db.NewTestDb(), utils.GOERLI)
+		chain := NewBlockchain(db.NewTestDb(), utils.GOERLI, utils.NewNopZapLogger())
 		assert.Nil(t, chain.Height())
 	})
 	t.Run("return height of the blockchain's head", func(t *testing.T) {
-		clientBlock0, clientStateUpdate0 := new(clients.Block), new(clients.StateUpdate)
-		if err := json.Unmarshal(mainnetBlock0, clientBlock0); err != nil {
-			t.Fatal(err)
-		}
-		if err := json.Unmarshal(mainnetStateUpdate0, clientStateUpdate0); err != nil {
-			t.Fatal(err)
-		}
-		block0, err := gateway.AdaptBlock(clientBlock0)
-		if err != nil {
-			t.Fatal(err)
-		}
-		stateUpdate0, err := gateway.AdaptStateUpdate(clientStateUpdate0)
-		if err != nil {
-			t.Fatal(err)
-		}
+		block0, stateUpdate0 := gatewaytest.MainnetBlock(t, 0)
 		testDB := db.NewTestDb()
-		chain := NewBlockchain(testDB, utils.MAINNET)
+		chain := NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
 		assert.NoError(t, chain.Store(block0, stateUpdate0))
 
-		chain = NewBlockchain(testDB, utils.MAINNET)
+		chain = NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
 		assert.Equal(t, block0.Number, *chain.Height())
 	})
 }
@@ -99,7 +57,7 @@ func TestBlockDbKey(t *testing.T) {
 	for i := 0; i < 32; i++ {
 		bytes[i] = byte(i + 1)
 	}
-	key := &BlockDbKey{
+	key := &blockDbKey{
 		Number: 44,
 		Hash:   new(felt.Felt).SetBytes(bytes[:]),
 	}
@@ -112,7 +70,7 @@ func TestBlockDbKey(t *testing.T) {
 	}
 	assert.Equal(t, expectedKeyB, keyB)
 	assert.NoError(t, err)
-	keyUnmarshaled := new(BlockDbKey)
+	keyUnmarshaled := new(blockDbKey)
 	require.NoError(t, keyUnmarshaled.UnmarshalBinary(keyB))
 	assert.Equal(t, key, keyUnmarshaled)
 
@@ -138,7 +96,7 @@ func TestVerifyBlock(t *testing.T) {
 	sr2, err := new(felt.Felt).SetRandom()
 	require.NoError(t, err)
 
-	chain := NewBlockchain(db.NewTestDb(), utils.GOERLI)
+	chain := NewBlockchain(db.NewTestDb(), utils.GOERLI, utils.NewNopZapLogger())
 
 	t.Run("error if chain is empty and incoming block number is not 0", func(t *testing.T) {
 		block := &core.Block{Number: 10}
@@ -146,6 +104,15 @@ func TestVerifyBlock(t *testing.T) {
 		assert.EqualError(t, chain.VerifyBlock(block, nil), expectedErr.Error())
 	})
 
+	t.Run("error if number of transactions and receipts do not match", func(t *testing.T) {
+		block := &core.Block{
+			Transactions: []core.Transaction{&core.InvokeTransaction{}},
+			Receipts:     []*core.TransactionReceipt{},
+		}
+		expectedErr := &ErrIncompatibleBlock{"number of transactions and transaction receipts do not match"}
+		assert.EqualError(t, chain.VerifyBlock(block, nil), expectedErr.Error())
+	})
+
 	t.Run("error if chain is empty and incoming block parent's hash is not 0", func(t *testing.T) {
 		block := &core.Block{ParentHash: h2}
 		expectedErr := &ErrIncompatibleBlock{"cannot insert a block with non-zero parent hash in an empty blockchain"}
@@ -157,13 +124,7 @@ func TestVerifyBlock(t *testing.T) {
 			headBlock := &core.Block{Number: 2, Hash: h1}
 			incomingBlock := &core.Block{Number: 10, ParentHash: h2}
 
-			assert.NoError(t, chain.database.Update(func(txn db.Transaction) error {
-				blockBinary, err := encoder.Marshal(headBlock)
-				if err != nil {
-					return err
-				}
-				return txn.Set(db.HeadBlock.Key(), blockBinary)
-			}))
+			setHead(t, chain, headBlock)
 			expectedErr := &ErrIncompatibleBlock{
 				"block number difference between head and incoming block is not 1",
 			}
@@ -173,13 +134,7 @@ func TestVerifyBlock(t *testing.T) {
 	t.Run("error when head hash does not match incoming block's parent hash", func(t *testing.T) {
 		headBlock := &core.Block{Hash: h1, Number: 1}
 		incomingBlock := &core.Block{ParentHash: h2, Number: 2}
-		assert.NoError(t, chain.database.Update(func(txn db.Transaction) error {
-			blockBinary, err := encoder.Marshal(headBlock)
-			if err != nil {
-				return err
-			}
-			return txn.Set(db.HeadBlock.Key(), blockBinary)
-		}))
+		setHead(t, chain, headBlock)
 		expectedErr := &ErrIncompatibleBlock{
 			"block's parent hash does not match head block hash",
 		}
@@ -190,13 +145,7 @@ func TestVerifyBlock(t *testing.T) {
 		headBlock := &core.Block{Hash: h1}
 		block := &core.Block{Number: 1, ParentHash: h1, Hash: h2}
 		stateUpdate := &core.StateUpdate{BlockHash: h3}
-		assert.NoError(t, chain.database.Update(func(txn db.Transaction) error {
-			blockBinary, err := encoder.Marshal(headBlock)
-			if err != nil {
-				return err
-			}
-			return txn.Set(db.HeadBlock.Key(), blockBinary)
-		}))
+		setHead(t, chain, headBlock)
 		expectedErr := ErrIncompatibleBlockAndStateUpdate{"block hashes do not match"}
 		assert.EqualError(t, chain.VerifyBlock(block, stateUpdate), expectedErr.Error())
 	})
@@ -206,13 +155,7 @@ func TestVerifyBlock(t *testing.T) {
 			headBlock := &core.Block{Hash: h1}
 			block := &core.Block{Number: 1, ParentHash: h1, Hash: h2, GlobalStateRoot: sr1}
 			stateUpdate := &core.StateUpdate{BlockHash: h2, NewRoot: sr2}
-			assert.NoError(t, chain.database.Update(func(txn db.Transaction) error {
-				blockBinary, err := encoder.Marshal(headBlock)
-				if err != nil {
-					return err
-				}
-				return txn.Set(db.HeadBlock.Key(), blockBinary)
-			}))
+			setHead(t, chain, headBlock)
 			expectedErr := ErrIncompatibleBlockAndStateUpdate{
 				"block's GlobalStateRoot does not match state update's NewRoot",
 			}
@@ -222,13 +165,7 @@ func TestVerifyBlock(t *testing.T) {
 		headBlock := &core.Block{Number: 119801, Hash: h1}
 		block := &core.Block{Number: 119802, ParentHash: h1, Hash: h2, GlobalStateRoot: sr1}
 		stateUpdate := &core.StateUpdate{BlockHash: h2, NewRoot: sr1}
-		assert.NoError(t, chain.database.Update(func(txn db.Transaction) error {
-			blockBinary, err := encoder.Marshal(headBlock)
-			if err != nil {
-				return err
-			}
-			return txn.Set(db.HeadBlock.Key(), blockBinary)
-		}))
+		setHead(t, chain, headBlock)
 		assert.NoError(t, chain.VerifyBlock(block, stateUpdate))
 	})
 	t.Run("error if block hash has not being calculated properly", func(t *testing.T) {
@@ -242,13 +179,7 @@ func TestVerifyBlock(t *testing.T) {
 			GlobalStateRoot:       sr1,
 		}
 		stateUpdate := &core.StateUpdate{BlockHash: h2, NewRoot: sr1}
-		assert.NoError(t, chain.database.Update(func(txn db.Transaction) error {
-			blockBinary, err := encoder.Marshal(headBlock)
-			if err != nil {
-				return err
-			}
-			return txn.Set(db.HeadBlock.Key(), blockBinary)
-		}))
+		setHead(t, chain, headBlock)
 		h, err := core.BlockHash(block, utils.GOERLI)
 		assert.NoError(t, err)
 		expectedErr := &ErrIncompatibleBlock{fmt.Sprintf(
@@ -258,25 +189,32 @@ func TestVerifyBlock(t *testing.T) {
 	})
 }
 
+// setHead stores block as the head of chain without verifying it.
+func setHead(t *testing.T, chain *Blockchain, block *core.Block) {
+	require.NoError(t, chain.database.Update(func(txn db.Transaction) error {
+		bKey, err := (&blockDbKey{block.Number, block.Hash}).MarshalBinary()
+		if err != nil {
+			return err
+		}
+		blockBinary, err := encoder.Marshal(block)
+		if err != nil {
+			return err
+		}
+		if err = txn.Set(bKey, blockBinary); err != nil {
+			return err
+		}
+		if err = indexBlock(txn, block); err != nil {
+			return err
+		}
+		return txn.Set(db.HeadBlock.Key(), encoder.Uint64Bytes(block.Number))
+	}))
+}
+
 func TestStore(t *testing.T) {
-	clientBlock0, clientStateUpdate0 := new(clients.Block), new(clients.StateUpdate)
-	if err := json.Unmarshal(mainnetBlock0, clientBlock0); err != nil {
-		t.Fatal(err)
-	}
-	if err := json.Unmarshal(mainnetStateUpdate0, clientStateUpdate0); err != nil {
-		t.Fatal(err)
-	}
-	block0, err := gateway.AdaptBlock(clientBlock0)
-	if err != nil {
-		t.Fatal(err)
-	}
-	stateUpdate0, err := gateway.AdaptStateUpdate(clientStateUpdate0)
-	if err != nil {
-		t.Fatal(err)
-	}
+	block0, stateUpdate0 := gatewaytest.MainnetBlock(t, 0)
 
 	t.Run("add block to empty blockchain", func(t *testing.T) {
-		chain := NewBlockchain(db.NewTestDb(), utils.MAINNET)
+		chain := NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
 		assert.NoError(t, chain.Store(block0, stateUpdate0))
 
 		headBlock, err := chain.Head()
@@ -290,18 +228,13 @@ func TestStore(t *testing.T) {
 		assert.Equal(t, stateUpdate0.NewRoot, root)
 
 		assert.NoError(t, chain.database.View(func(txn db.Transaction) error {
-			databaseHeadBlock, err := txn.Get(db.HeadBlock.Key())
+			databaseHead, err := txn.Get(db.HeadBlock.Key())
 			if err != nil {
 				return err
 			}
+			assert.Equal(t, encoder.Uint64Bytes(block0.Number), databaseHead)
 
-			headBlock := new(core.Block)
-			if err = encoder.Unmarshal(databaseHeadBlock, headBlock); err != nil {
-				return err
-			}
-			assert.Equal(t, headBlock, block0)
-
-			block0Key := &BlockDbKey{block0.Number, block0.Hash}
+			block0Key := &blockDbKey{block0.Number, block0.Hash}
 			k, err := block0Key.MarshalBinary()
 			if err != nil {
 				return err
@@ -321,23 +254,9 @@ func TestStore(t *testing.T) {
 		}))
 	})
 	t.Run("add block to non-empty blockchain", func(t *testing.T) {
-		clientBlock1, clientStateUpdate1 := new(clients.Block), new(clients.StateUpdate)
-		if err := json.Unmarshal(mainnetBlock1, clientBlock1); err != nil {
-			t.Fatal(err)
-		}
-		if err := json.Unmarshal(mainnetStateUpdate1, clientStateUpdate1); err != nil {
-			t.Fatal(err)
-		}
-		block1, err := gateway.AdaptBlock(clientBlock1)
-		if err != nil {
-			t.Fatal(err)
-		}
-		stateUpdate1, err := gateway.AdaptStateUpdate(clientStateUpdate1)
-		if err != nil {
-			t.Fatal(err)
-		}
+		block1, stateUpdate1 := gatewaytest.MainnetBlock(t, 1)
 
-		chain := NewBlockchain(db.NewTestDb(), utils.MAINNET)
+		chain := NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
 		assert.NoError(t, chain.Store(block0, stateUpdate0))
 		assert.NoError(t, chain.Store(block1, stateUpdate1))
 
@@ -352,18 +271,13 @@ func TestStore(t *testing.T) {
 		assert.Equal(t, stateUpdate1.NewRoot, root)
 
 		assert.NoError(t, chain.database.View(func(txn db.Transaction) error {
-			databaseHeadBlock, err := txn.Get(db.HeadBlock.Key())
+			databaseHead, err := txn.Get(db.HeadBlock.Key())
 			if err != nil {
 				return err
 			}
+			assert.Equal(t, encoder.Uint64Bytes(block1.Number), databaseHead)
 
-			headBlock := new(core.Block)
-			if err = encoder.Unmarshal(databaseHeadBlock, headBlock); err != nil {
-				return err
-			}
-			assert.Equal(t, headBlock, block1)
-
-			block1Key := &BlockDbKey{block1.Number, block1.Hash}
+			block1Key := &blockDbKey{block1.Number, block1.Hash}
 			k, err := block1Key.MarshalBinary()
 			if err != nil {
 				return err
@@ -382,4 +296,199 @@ func TestStore(t *testing.T) {
 			return nil
 		}))
 	})
+
+	t.Run("only Store verifies the block hash", func(t *testing.T) {
+		wrongHash := new(felt.Felt).SetUint64(44)
+		block, stateUpdate := *block0, *stateUpdate0
+		block.Hash, stateUpdate.BlockHash = wrongHash, wrongHash
+
+		chain := NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+		assert.ErrorAs(t, chain.Store(&block, &stateUpdate), new(*ErrIncompatibleBlock))
+		_, err := chain.Head()
+		assert.ErrorIs(t, err, db.ErrKeyNotFound)
+
+		require.NoError(t, chain.StoreVerified(&block, &stateUpdate))
+		head, err := chain.Head()
+		require.NoError(t, err)
+		assert.Equal(t, wrongHash, head.Hash)
+	})
+}
+
+func TestBlockByNumberAndHash(t *testing.T) {
+	block0, stateUpdate0 := gatewaytest.MainnetBlock(t, 0)
+
+	block1, stateUpdate1 := gatewaytest.MainnetBlock(t, 1)
+
+	chain := NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+	require.NoError(t, chain.Store(block0, stateUpdate0))
+	require.NoError(t, chain.Store(block1, stateUpdate1))
+
+	t.Run("BlockByNumber", func(t *testing.T) {
+		for _, expected := range []*core.Block{block0, block1} {
+			got, err := chain.BlockByNumber(expected.Number)
+			require.NoError(t, err)
+			assert.Equal(t, expected, got)
+		}
+
+		_, err := chain.BlockByNumber(2)
+		assert.EqualError(t, err, "block not found: number 2")
+		assert.ErrorAs(t, err, new(*ErrBlockNotFound))
+		assert.NotErrorIs(t, err, db.ErrKeyNotFound)
+	})
+
+	t.Run("BlockByHash", func(t *testing.T) {
+		for _, expected := range []*core.Block{block0, block1} {
+			got, err := chain.BlockByHash(expected.Hash)
+			require.NoError(t, err)
+			assert.Equal(t, expected, got)
+		}
+
+		_, err := chain.BlockByHash(new(felt.Felt).SetUint64(44))
+		assert.EqualError(t, err, "block not found: hash 2c")
+		assert.ErrorAs(t, err, new(*ErrBlockNotFound))
+		assert.NotErrorIs(t, err, db.ErrKeyNotFound)
+	})
+
+	t.Run("StateUpdateByNumber", func(t *testing.T) {
+		for i, expected := range []*core.StateUpdate{stateUpdate0, stateUpdate1} {
+			got, err := chain.StateUpdateByNumber(uint64(i))
+			require.NoError(t, err)
+			assert.Equal(t, expected, got)
+		}
+
+		_, err := chain.StateUpdateByNumber(2)
+		assert.ErrorAs(t, err, new(*ErrBlockNotFound))
+	})
+}
+
+func TestTransactionAndReceipt(t *testing.T) {
+	block0, stateUpdate0 := gatewaytest.MainnetBlock(t, 0)
+
+	chain := NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+	require.NoError(t, chain.Store(block0, stateUpdate0))
+	require.NotEmpty(t, block0.Transactions)
+
+	t.Run("TransactionByBlockNumberAndIndex", func(t *testing.T) {
+		for i, expected := range block0.Transactions {
+			got, err := chain.TransactionByBlockNumberAndIndex(0, uint64(i))
+			require.NoError(t, err)
+			assert.Equal(t, expected, got)
+		}
+
+		_, err := chain.TransactionByBlockNumberAndIndex(0, uint64(len(block0.Transactions)))
+		assert.ErrorAs(t, err, new(*ErrTransactionNotFound))
+
+		_, err = chain.TransactionByBlockNumberAndIndex(1, 0)
+		assert.ErrorAs(t, err, new(*ErrBlockNotFound))
+	})
+
+	t.Run("TransactionByHash and ReceiptByHash", func(t *testing.T) {
+		for i, expectedReceipt := range block0.Receipts {
+			gotTx, err := chain.TransactionByHash(expectedReceipt.TransactionHash)
+			require.NoError(t, err)
+			assert.Equal(t, block0.Transactions[i], gotTx)
+
+			gotReceipt, err := chain.ReceiptByHash(expectedReceipt.TransactionHash)
+			require.NoError(t, err)
+			assert.Equal(t, expectedReceipt, gotReceipt)
+		}
+	})
+
+	t.Run("unknown hash", func(t *testing.T) {
+		_, err := chain.TransactionByHash(new(felt.Felt).SetUint64(44))
+		assert.EqualError(t, err, "transaction not found: hash 2c")
+		assert.ErrorAs(t, err, new(*ErrTransactionNotFound))
+
+		_, err = chain.ReceiptByHash(new(felt.Felt).SetUint64(44))
+		assert.ErrorAs(t, err, new(*ErrTransactionNotFound))
+	})
+}
+
+func TestRevertHead(t *testing.T) {
+	block0, stateUpdate0 := gatewaytest.MainnetBlock(t, 0)
+
+	block1, stateUpdate1 := gatewaytest.MainnetBlock(t, 1)
+
+	testDB := db.NewTestDb()
+	chain := NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
+
+	stateRoot := func(t *testing.T) *felt.Felt {
+		var root *felt.Felt
+		require.NoError(t, testDB.View(func(txn db.Transaction) error {
+			var err error
+			root, err = state.NewState(txn).Root()
+			return err
+		}))
+		return root
+	}
+
+	t.Run("empty blockchain", func(t *testing.T) {
+		assert.ErrorIs(t, chain.RevertHead(), db.ErrKeyNotFound)
+	})
+
+	require.NoError(t, chain.Store(block0, stateUpdate0))
+	require.NoError(t, chain.Store(block1, stateUpdate1))
+
+	t.Run("revert block 1", func(t *testing.T) {
+		require.NoError(t, chain.RevertHead())
+
+		head, err := chain.Head()
+		require.NoError(t, err)
+		assert.Equal(t, block0, head)
+		assert.Equal(t, stateUpdate1.OldRoot, stateRoot(t))
+
+		_, err = chain.BlockByNumber(1)
+		assert.ErrorAs(t, err, new(*ErrBlockNotFound))
+		_, err = chain.BlockByHash(block1.Hash)
+		assert.ErrorAs(t, err, new(*ErrBlockNotFound))
+		_, err = chain.StateUpdateByNumber(1)
+		assert.ErrorAs(t, err, new(*ErrBlockNotFound))
+		_, err = chain.TransactionByHash(block1.Receipts[0].TransactionHash)
+		assert.ErrorAs(t, err, new(*ErrTransactionNotFound))
+	})
+
+	t.Run("store block 1 again", func(t *testing.T) {
+		require.NoError(t, chain.Store(block1, stateUpdate1))
+		assert.Equal(t, stateUpdate1.NewRoot, stateRoot(t))
+	})
+
+	t.Run("revert all blocks", func(t *testing.T) {
+		require.NoError(t, chain.RevertHead())
+		require.NoError(t, chain.RevertHead())
+
+		_, err := chain.Head()
+		assert.ErrorIs(t, err, db.ErrKeyNotFound)
+		assert.Equal(t, new(felt.Felt), stateRoot(t))
+
+		require.NoError(t, chain.Store(block0, stateUpdate0))
+	})
+
+	t.Run("blocks accepted on L1 are final", func(t *testing.T) {
+		require.NoError(t, chain.Store(block1, stateUpdate1))
+		require.NoError(t, chain.SetL1Head(&L1Head{BlockNumber: 0, StateRoot: block0.GlobalStateRoot}))
+
+		require.NoError(t, chain.RevertHead())
+		assert.ErrorIs(t, chain.RevertHead(), ErrRevertingL1Block)
+
+		head, err := chain.Head()
+		require.NoError(t, err)
+		assert.Equal(t, block0.Hash, head.Hash)
+		assert.Equal(t, stateUpdate0.NewRoot, stateRoot(t))
+	})
+}
+
+// storeMainnetBlocks stores the first count mainnet blocks, accepted on L2, in chain.
+func storeMainnetBlocks(t *testing.T, chain *Blockchain, count uint64) ([]*core.Block, []*core.StateUpdate) {
+	t.Helper()
+
+	var blocks []*core.Block
+	var stateUpdates []*core.StateUpdate
+	for number := uint64(0); number < count; number++ {
+		block, stateUpdate := gatewaytest.MainnetBlock(t, number)
+		block.Status = core.StatusAcceptedOnL2
+		require.NoError(t, chain.Store(block, stateUpdate))
+		blocks = append(blocks, block)
+		stateUpdates = append(stateUpdates, stateUpdate)
+	}
+	return blocks, stateUpdates
 }
@@ -0,0 +1,181 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/encoder"
+)
+
+// ErrInvalidContinuationToken is returned when a continuation token passed to
+// [Blockchain.Events] cannot be parsed or does not belong to the filtered range.
+type ErrInvalidContinuationToken struct {
+	token string
+}
+
+func (e ErrInvalidContinuationToken) Error() string {
+	return fmt.Sprintf("invalid continuation token: %q", e.token)
+}
+
+// EventFilter selects the events returned by [Blockchain.Events].
+type EventFilter struct {
+	// The first block to search, inclusive. Defaults to the genesis block.
+	FromBlock *uint64
+	// The last block to search, inclusive. Defaults to the head of the blockchain.
+	ToBlock *uint64
+	// The address of the contract which emitted the events. Any address matches if nil.
+	Address *felt.Felt
+	// Keys[i] is the set of values accepted for the i-th key of an event.
+	// An empty set matches any value.
+	Keys [][]*felt.Felt
+}
+
+// FilteredEvent is an event matching an [EventFilter] along with where it was emitted.
+type FilteredEvent struct {
+	*core.Event
+	BlockNumber     uint64
+	BlockHash       *felt.Felt
+	TransactionHash *felt.Felt
+}
+
+// Events returns at most chunkSize events matching filter, in the order they were emitted.
+// If there are more matching events, a continuation token is returned as well which can be
+// passed to a subsequent call with the same filter to get the next chunk of events.
+func (b *Blockchain) Events(filter *EventFilter, continuationToken string,
+	chunkSize uint64,
+) ([]*FilteredEvent, string, error) {
+	if chunkSize == 0 {
+		return nil, "", errors.New("chunk size must be greater than 0")
+	}
+
+	var events []*FilteredEvent
+	var nextToken string
+	return events, nextToken, b.database.View(func(txn db.Transaction) error {
+		head, err := b.head(txn)
+		if err != nil {
+			if errors.Is(err, db.ErrKeyNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		fromBlock, toBlock := uint64(0), head.Number
+		if filter.FromBlock != nil {
+			fromBlock = *filter.FromBlock
+		}
+		if filter.ToBlock != nil && *filter.ToBlock < toBlock {
+			toBlock = *filter.ToBlock
+		}
+
+		var eventIndex uint64
+		if continuationToken != "" {
+			var blockNumber uint64
+			blockNumber, eventIndex, err = parseContinuationToken(continuationToken)
+			if err != nil || blockNumber < fromBlock || blockNumber > toBlock {
+				return &ErrInvalidContinuationToken{continuationToken}
+			}
+			fromBlock = blockNumber
+		}
+
+		for number := fromBlock; number <= toBlock; number++ {
+			var found bool
+			if number, found, err = nextEventBlock(txn, filter.Address, number); err != nil {
+				return err
+			} else if !found || number > toBlock {
+				return nil
+			}
+
+			block, err := blockByNumber(txn, number)
+			if err != nil {
+				return err
+			}
+
+			var index uint64
+			for _, receipt := range block.Receipts {
+				for _, event := range receipt.Events {
+					if index >= eventIndex && filter.matches(event) {
+						if uint64(len(events)) == chunkSize {
+							nextToken = fmt.Sprintf("%d-%d", number, index)
+							return nil
+						}
+						events = append(events, &FilteredEvent{
+							Event:           event,
+							BlockNumber:     number,
+							BlockHash:       block.Hash,
+							TransactionHash: receipt.TransactionHash,
+						})
+					}
+					index++
+				}
+			}
+			eventIndex = 0
+		}
+		return nil
+	})
+}
+
+// nextEventBlock returns the first block starting from number that may include events
+// emitted by address. All blocks are candidates if address is nil.
+func nextEventBlock(txn db.Transaction, address *felt.Felt, number uint64) (uint64, bool, error) {
+	if address == nil {
+		return number, true, nil
+	}
+
+	prefix := db.ContractEventBlocks.Key(address.Marshal())
+	entry, err := txn.Seek(db.ContractEventBlocks.Key(address.Marshal(), encoder.Uint64Bytes(number)))
+	if err != nil || entry == nil || !bytes.HasPrefix(entry.Key, prefix) {
+		return 0, false, err
+	}
+	return binary.BigEndian.Uint64(entry.Key[len(prefix):]), true, nil
+}
+
+func (f *EventFilter) matches(event *core.Event) bool {
+	if f.Address != nil && !f.Address.Equal(event.From) {
+		return false
+	}
+
+	for i, keys := range f.Keys {
+		if len(keys) == 0 {
+			continue
+		}
+		if i >= len(event.Keys) {
+			return false
+		}
+
+		var match bool
+		for _, key := range keys {
+			if key.Equal(event.Keys[i]) {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+func parseContinuationToken(token string) (uint64, uint64, error) {
+	blockStr, indexStr, ok := strings.Cut(token, "-")
+	if !ok {
+		return 0, 0, errors.New("missing separator")
+	}
+
+	blockNumber, err := strconv.ParseUint(blockStr, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	eventIndex, err := strconv.ParseUint(indexStr, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return blockNumber, eventIndex, nil
+}
@@ -0,0 +1,125 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/starknetdata/gateway/gatewaytest"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents(t *testing.T) {
+	chain := NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+
+	t.Run("empty blockchain", func(t *testing.T) {
+		events, token, err := chain.Events(&EventFilter{}, "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+		assert.Empty(t, token)
+	})
+
+	// Mainnet blocks 0 and 1 have no events, so we attach some to their receipts.
+	// Events are not part of the block hash, so the blocks can still be stored.
+	addr1, addr2 := new(felt.Felt).SetUint64(1), new(felt.Felt).SetUint64(2)
+	key1, key2 := new(felt.Felt).SetUint64(11), new(felt.Felt).SetUint64(12)
+	blocks := make([]*core.Block, 2)
+	for i := range blocks {
+		block, stateUpdate := gatewaytest.MainnetBlock(t, uint64(i))
+
+		// addr2 only emits events in block 0
+		from := addr2
+		if i > 0 {
+			from = addr1
+		}
+		block.Receipts[0].Events = []*core.Event{
+			{From: addr1, Keys: []*felt.Felt{key1}},
+			{From: from, Keys: []*felt.Felt{key2, key1}},
+		}
+		block.Receipts[1].Events = []*core.Event{{From: addr1, Keys: []*felt.Felt{key2}}}
+		require.NoError(t, chain.Store(block, stateUpdate))
+		blocks[i] = block
+	}
+
+	allEvents := func(t *testing.T, filter *EventFilter, chunkSize uint64) []*FilteredEvent {
+		var events []*FilteredEvent
+		token := ""
+		for {
+			chunk, next, err := chain.Events(filter, token, chunkSize)
+			require.NoError(t, err)
+			require.LessOrEqual(t, uint64(len(chunk)), chunkSize)
+			events = append(events, chunk...)
+			if next == "" {
+				return events
+			}
+			token = next
+		}
+	}
+
+	t.Run("no filter", func(t *testing.T) {
+		for _, chunkSize := range []uint64{1, 2, 6, 10} {
+			events := allEvents(t, &EventFilter{}, chunkSize)
+			require.Len(t, events, 6)
+			assert.Equal(t, uint64(0), events[0].BlockNumber)
+			assert.Equal(t, blocks[0].Hash, events[0].BlockHash)
+			assert.Equal(t, blocks[0].Receipts[0].TransactionHash, events[0].TransactionHash)
+			assert.Equal(t, blocks[0].Receipts[1].TransactionHash, events[2].TransactionHash)
+			assert.Equal(t, uint64(1), events[5].BlockNumber)
+		}
+	})
+
+	t.Run("block range", func(t *testing.T) {
+		one := uint64(1)
+		events := allEvents(t, &EventFilter{FromBlock: &one}, 2)
+		require.Len(t, events, 3)
+		for _, event := range events {
+			assert.Equal(t, one, event.BlockNumber)
+		}
+
+		zero := uint64(0)
+		events = allEvents(t, &EventFilter{ToBlock: &zero}, 2)
+		require.Len(t, events, 3)
+		for _, event := range events {
+			assert.Equal(t, zero, event.BlockNumber)
+		}
+	})
+
+	t.Run("address", func(t *testing.T) {
+		events := allEvents(t, &EventFilter{Address: addr2}, 1)
+		require.Len(t, events, 1)
+		assert.Equal(t, uint64(0), events[0].BlockNumber)
+		assert.Equal(t, addr2, events[0].From)
+
+		events = allEvents(t, &EventFilter{Address: new(felt.Felt).SetUint64(3)}, 1)
+		assert.Empty(t, events)
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		events := allEvents(t, &EventFilter{Keys: [][]*felt.Felt{{key1}}}, 10)
+		assert.Len(t, events, 2)
+
+		events = allEvents(t, &EventFilter{Keys: [][]*felt.Felt{{key1, key2}}}, 10)
+		assert.Len(t, events, 6)
+
+		events = allEvents(t, &EventFilter{Keys: [][]*felt.Felt{{}, {key1}}}, 10)
+		assert.Len(t, events, 2)
+
+		events = allEvents(t, &EventFilter{Address: addr1, Keys: [][]*felt.Felt{{key2}}}, 10)
+		assert.Len(t, events, 3)
+	})
+
+	t.Run("invalid continuation token", func(t *testing.T) {
+		for _, token := range []string{"abc", "1", "1-a", "5-0"} {
+			_, _, err := chain.Events(&EventFilter{}, token, 10)
+			assert.ErrorAs(t, err, new(*ErrInvalidContinuationToken))
+		}
+	})
+
+	t.Run("zero chunk size", func(t *testing.T) {
+		_, _, err := chain.Events(&EventFilter{}, "", 0)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,116 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/encoder"
+)
+
+// L1Head is the latest block whose state update has been verified on L1.
+// The block and all of its ancestors are accepted on L1.
+type L1Head struct {
+	BlockNumber uint64
+	StateRoot   *felt.Felt
+}
+
+// L1Head gets the latest block verified on L1. If no block has been
+// verified yet, db.ErrKeyNotFound is returned.
+func (b *Blockchain) L1Head() (*L1Head, error) {
+	var head *L1Head
+	return head, b.database.View(func(txn db.Transaction) error {
+		var err error
+		head, err = l1Head(txn)
+		return err
+	})
+}
+
+func l1Head(txn db.Transaction) (*L1Head, error) {
+	headBinary, err := txn.Get(db.L1Head.Key())
+	if err != nil {
+		return nil, err
+	}
+
+	head := new(L1Head)
+	if err = encoder.Unmarshal(headBinary, head); err != nil {
+		return nil, err
+	}
+	return head, nil
+}
+
+// SetL1Head records head as the latest block verified on L1 and marks the block and its ancestors as
+// accepted on L1. The block must be stored and its
+// global state root must match the state root verified on L1, otherwise [ErrBlockNotFound] or
+// [ErrIncompatibleBlock] is returned respectively.
+//
+// Blocks up to the previous L1 head are already accepted on L1. The blocks after it are marked in
+// batches from the lowest one up, so that the first L1 head of a long chain does not need a single
+// huge transaction, and so that an interrupted update is resumed by the next call.
+func (b *Blockchain) SetL1Head(head *L1Head) error {
+	var next uint64
+	err := b.database.View(func(txn db.Transaction) error {
+		if err := checkL1Head(txn, head); err != nil {
+			return err
+		}
+
+		previous, err := l1Head(txn)
+		if err == nil {
+			next = previous.BlockNumber + 1
+		} else if !errors.Is(err, db.ErrKeyNotFound) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for done := false; !done; {
+		err = b.database.Update(func(txn db.Transaction) error {
+			for writes := 0; writes < maxBatchWrites; writes++ {
+				if next > head.BlockNumber {
+					done = true
+					// the block may have been replaced in the meantime
+					if err := checkL1Head(txn, head); err != nil {
+						return err
+					}
+
+					headBinary, err := encoder.Marshal(head)
+					if err != nil {
+						return err
+					}
+					return txn.Set(db.L1Head.Key(), headBinary)
+				}
+
+				status := []byte{byte(core.StatusAcceptedOnL1)}
+				if err := txn.Set(db.BlockStatuses.Key(encoder.Uint64Bytes(next)), status); err != nil {
+					return err
+				}
+				next++
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkL1Head checks that the block verified on L1 is stored and has the verified state root.
+func checkL1Head(txn db.Transaction, head *L1Head) error {
+	block, err := blockByNumber(txn, head.BlockNumber)
+	if err != nil {
+		return err
+	}
+	if !block.GlobalStateRoot.Equal(head.StateRoot) {
+		return &ErrIncompatibleBlock{
+			fmt.Sprintf("global state root of block %d does not match the state root %v verified on L1",
+				head.BlockNumber, head.StateRoot.Text(16)),
+		}
+	}
+	return nil
+}
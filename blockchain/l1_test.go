@@ -0,0 +1,76 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestL1Head(t *testing.T) {
+	chain := NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+
+	t.Run("no L1 head", func(t *testing.T) {
+		head, err := chain.L1Head()
+		assert.Nil(t, head)
+		assert.ErrorIs(t, err, db.ErrKeyNotFound)
+	})
+
+	t.Run("block is not stored", func(t *testing.T) {
+		err := chain.SetL1Head(&L1Head{BlockNumber: 0, StateRoot: new(felt.Felt)})
+		assert.ErrorAs(t, err, new(*ErrBlockNotFound))
+	})
+
+	storeMainnetBlocks(t, chain, 2)
+	block1, err := chain.BlockByNumber(1)
+	require.NoError(t, err)
+
+	t.Run("state root mismatch", func(t *testing.T) {
+		err := chain.SetL1Head(&L1Head{BlockNumber: 1, StateRoot: new(felt.Felt).SetUint64(44)})
+		assert.ErrorAs(t, err, new(*ErrIncompatibleBlock))
+		_, err = chain.L1Head()
+		assert.ErrorIs(t, err, db.ErrKeyNotFound)
+	})
+
+	t.Run("set L1 head", func(t *testing.T) {
+		expected := &L1Head{BlockNumber: 1, StateRoot: block1.GlobalStateRoot}
+		require.NoError(t, chain.SetL1Head(expected))
+		head, err := chain.L1Head()
+		require.NoError(t, err)
+		assert.Equal(t, expected, head)
+	})
+
+	t.Run("L1 head cannot be reverted", func(t *testing.T) {
+		assert.ErrorIs(t, chain.RevertHead(), ErrRevertingL1Block)
+		head, err := chain.Head()
+		require.NoError(t, err)
+		assert.Equal(t, block1.Hash, head.Hash)
+		l1Head, err := chain.L1Head()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), l1Head.BlockNumber)
+	})
+}
+
+func TestSetL1HeadInBatches(t *testing.T) {
+	defer func(writes int) {
+		maxBatchWrites = writes
+	}(maxBatchWrites)
+	maxBatchWrites = 1
+
+	chain := NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+	blocks, _ := storeMainnetBlocks(t, chain, 2)
+
+	require.NoError(t, chain.SetL1Head(&L1Head{BlockNumber: 1, StateRoot: blocks[1].GlobalStateRoot}))
+	for _, block := range blocks {
+		status, err := chain.BlockStatus(block.Number)
+		require.NoError(t, err)
+		assert.Equal(t, core.StatusAcceptedOnL1, status)
+	}
+	head, err := chain.L1Head()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), head.BlockNumber)
+}
@@ -0,0 +1,46 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/encoder"
+)
+
+// schemaVersion is the version of the database layout written by this version of Juno. It has to
+// be bumped whenever the layout changes in a way older databases cannot be read with.
+const schemaVersion uint64 = 2
+
+// ErrIncompatibleDatabase is returned by [Blockchain.CheckSchemaVersion] when the database was
+// written by a version of Juno with a different layout. It has to be removed and synced again.
+var ErrIncompatibleDatabase = errors.New("database is incompatible with this version of Juno, " +
+	"remove it and sync again")
+
+// CheckSchemaVersion makes sure the database was written with the current layout, and records
+// the layout of an empty database. It has to be called before the blockchain is used.
+//
+// Databases written before the schema version was recorded do not contain the state updates,
+// indexes and history the blockchain relies on, so they are rejected as well.
+func (b *Blockchain) CheckSchemaVersion() error {
+	return b.database.Update(func(txn db.Transaction) error {
+		versionBinary, err := txn.Get(db.SchemaVersion.Key())
+		if err == nil {
+			if version := binary.BigEndian.Uint64(versionBinary); version != schemaVersion {
+				return fmt.Errorf("%w: found schema version %d, expected %d", ErrIncompatibleDatabase,
+					version, schemaVersion)
+			}
+			return nil
+		} else if !errors.Is(err, db.ErrKeyNotFound) {
+			return err
+		}
+
+		if _, err = txn.Get(db.HeadBlock.Key()); err == nil {
+			return fmt.Errorf("%w: no schema version found", ErrIncompatibleDatabase)
+		} else if !errors.Is(err, db.ErrKeyNotFound) {
+			return err
+		}
+		return txn.Set(db.SchemaVersion.Key(), encoder.Uint64Bytes(schemaVersion))
+	})
+}
@@ -0,0 +1,42 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/encoder"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSchemaVersion(t *testing.T) {
+	t.Run("empty database", func(t *testing.T) {
+		testDB := db.NewTestDb()
+		chain := NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
+
+		require.NoError(t, chain.CheckSchemaVersion())
+		storeMainnetBlocks(t, chain, 2)
+		assert.NoError(t, chain.CheckSchemaVersion())
+	})
+
+	t.Run("database without schema version", func(t *testing.T) {
+		testDB := db.NewTestDb()
+		chain := NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
+
+		// a database written before the schema version was recorded
+		storeMainnetBlocks(t, chain, 1)
+		assert.ErrorIs(t, chain.CheckSchemaVersion(), ErrIncompatibleDatabase)
+	})
+
+	t.Run("database with another schema version", func(t *testing.T) {
+		testDB := db.NewTestDb()
+		chain := NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
+
+		require.NoError(t, testDB.Update(func(txn db.Transaction) error {
+			return txn.Set(db.SchemaVersion.Key(), encoder.Uint64Bytes(schemaVersion+1))
+		}))
+		assert.EqualError(t, chain.CheckSchemaVersion(), ErrIncompatibleDatabase.Error()+
+			": found schema version 3, expected 2")
+	})
+}
@@ -0,0 +1,56 @@
+package blockchain
+
+import (
+	"errors"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/encoder"
+)
+
+// BlockStatus gets the status of the block with the given number.
+// If there is no such block, [ErrBlockNotFound] is returned.
+func (b *Blockchain) BlockStatus(number uint64) (core.BlockStatus, error) {
+	var status core.BlockStatus
+	return status, b.database.View(func(txn db.Transaction) error {
+		if _, err := txn.Get(db.BlockHashesByNumber.Key(encoder.Uint64Bytes(number))); err != nil {
+			if errors.Is(err, db.ErrKeyNotFound) {
+				return &ErrBlockNotFound{Number: &number}
+			}
+			return err
+		}
+
+		var err error
+		status, err = blockStatus(txn, number)
+		return err
+	})
+}
+
+// SetBlockStatus updates the status of the block with the given number.
+// If there is no such block, [ErrBlockNotFound] is returned.
+func (b *Blockchain) SetBlockStatus(number uint64, status core.BlockStatus) error {
+	return b.database.Update(func(txn db.Transaction) error {
+		numberBinary := encoder.Uint64Bytes(number)
+		if _, err := txn.Get(db.BlockHashesByNumber.Key(numberBinary)); err != nil {
+			if errors.Is(err, db.ErrKeyNotFound) {
+				return &ErrBlockNotFound{Number: &number}
+			}
+			return err
+		}
+		return txn.Set(db.BlockStatuses.Key(numberBinary), []byte{byte(status)})
+	})
+}
+
+// blockStatus gets the status of a stored block. Blocks stored without a status
+// are accepted on L2.
+func blockStatus(txn db.Transaction, number uint64) (core.BlockStatus, error) {
+	statusBinary, err := txn.Get(db.BlockStatuses.Key(encoder.Uint64Bytes(number)))
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return core.StatusAcceptedOnL2, nil
+	} else if err != nil {
+		return 0, err
+	} else if len(statusBinary) != 1 {
+		return 0, errors.New("malformed block status")
+	}
+	return core.BlockStatus(statusBinary[0]), nil
+}
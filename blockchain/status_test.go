@@ -0,0 +1,62 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockStatus(t *testing.T) {
+	chain := NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := chain.BlockStatus(0)
+		assert.ErrorAs(t, err, new(*ErrBlockNotFound))
+		assert.ErrorAs(t, chain.SetBlockStatus(0, core.StatusAcceptedOnL1), new(*ErrBlockNotFound))
+	})
+
+	blocks, stateUpdates := storeMainnetBlocks(t, chain, 2)
+
+	assertStatus := func(t *testing.T, number uint64, want core.BlockStatus) {
+		status, err := chain.BlockStatus(number)
+		require.NoError(t, err)
+		assert.Equal(t, want, status)
+
+		block, err := chain.BlockByNumber(number)
+		require.NoError(t, err)
+		assert.Equal(t, want, block.Status)
+	}
+
+	t.Run("status of stored blocks", func(t *testing.T) {
+		assertStatus(t, 0, core.StatusAcceptedOnL2)
+		assertStatus(t, 1, core.StatusAcceptedOnL2)
+	})
+
+	t.Run("set status", func(t *testing.T) {
+		require.NoError(t, chain.SetBlockStatus(1, core.StatusRejected))
+		assertStatus(t, 1, core.StatusRejected)
+
+		head, err := chain.Head()
+		require.NoError(t, err)
+		assert.Equal(t, core.StatusRejected, head.Status)
+	})
+
+	t.Run("reverted block has no status", func(t *testing.T) {
+		require.NoError(t, chain.RevertHead())
+		_, err := chain.BlockStatus(1)
+		assert.ErrorAs(t, err, new(*ErrBlockNotFound))
+
+		require.NoError(t, chain.Store(blocks[1], stateUpdates[1]))
+		assertStatus(t, 1, core.StatusAcceptedOnL2)
+	})
+
+	t.Run("L1 head marks ancestors as accepted on L1", func(t *testing.T) {
+		require.NoError(t, chain.SetL1Head(&L1Head{BlockNumber: 1, StateRoot: blocks[1].GlobalStateRoot}))
+		assertStatus(t, 0, core.StatusAcceptedOnL1)
+		assertStatus(t, 1, core.StatusAcceptedOnL1)
+	})
+}
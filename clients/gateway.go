@@ -1,29 +1,141 @@
 package clients
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"io/ioutil"
+	"io"
 	"math/big"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"path"
 	"strconv"
+	"time"
 
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/metrics"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
-const feederGatewayPath = "/feeder_gateway/"
+const (
+	feederGatewayPath = "/feeder_gateway/"
+
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 5
+	defaultMinWait    = 500 * time.Millisecond
+	defaultMaxWait    = 30 * time.Second
+)
+
+var (
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "gateway",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the requests sent to the feeder gateway, per endpoint.",
+	}, []string{"endpoint"})
+	requestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "gateway",
+		Name:      "request_errors_total",
+		Help:      "Number of failed requests sent to the feeder gateway, per endpoint.",
+	}, []string{"endpoint"})
+)
+
+// ErrorCode is the StarkNet error code the gateway returns when a query fails.
+type ErrorCode string
+
+const (
+	BlockNotFound   ErrorCode = "StarknetErrorCode.BLOCK_NOT_FOUND"
+	UndeclaredClass ErrorCode = "StarknetErrorCode.UNDECLARED_CLASS"
+)
+
+// Error is the error object returned by the gateway for a failed query.
+type Error struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (e Error) Error() string {
+	return string(e.Code) + ": " + e.Message
+}
+
+// IsBlockNotFound reports whether err is a gateway [Error] telling that the
+// requested block does not exist (yet).
+func IsBlockNotFound(err error) bool {
+	var gatewayErr *Error
+	return errors.As(err, &gatewayErr) && gatewayErr.Code == BlockNotFound
+}
+
+// TransientError is returned when a request still fails after all its retries
+// with an error that is expected to go away, such as a timeout or a 5xx response.
+type TransientError struct {
+	Err error
+}
+
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// IsTransient reports whether err is a [TransientError], i.e. whether the
+// request is worth sending again later.
+func IsTransient(err error) bool {
+	var transientErr *TransientError
+	return errors.As(err, &transientErr)
+}
 
 type GatewayClient struct {
-	baseUrl string
+	baseUrl    string
+	client     *http.Client
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+	log        utils.Logger
 }
 
 func NewGatewayClient(baseUrl string) *GatewayClient {
 	return &GatewayClient{
-		baseUrl: baseUrl + feederGatewayPath,
+		baseUrl:    baseUrl + feederGatewayPath,
+		client:     &http.Client{Timeout: defaultTimeout},
+		maxRetries: defaultMaxRetries,
+		minWait:    defaultMinWait,
+		maxWait:    defaultMaxWait,
+		log:        utils.NewNopZapLogger(),
 	}
 }
 
+// WithLogger sets the logger used to report failed requests.
+func (c *GatewayClient) WithLogger(log utils.Logger) *GatewayClient {
+	c.log = log
+	return c
+}
+
+// WithTimeout sets the timeout of a single request, including reading the response body.
+func (c *GatewayClient) WithTimeout(timeout time.Duration) *GatewayClient {
+	c.client.Timeout = timeout
+	return c
+}
+
+// WithMaxRetries sets how many times a request is retried after a transient failure
+// before giving up.
+func (c *GatewayClient) WithMaxRetries(maxRetries int) *GatewayClient {
+	c.maxRetries = maxRetries
+	return c
+}
+
+// WithBackoff sets the bounds of the exponential backoff between retries.
+func (c *GatewayClient) WithBackoff(minWait, maxWait time.Duration) *GatewayClient {
+	c.minWait = minWait
+	c.maxWait = maxWait
+	return c
+}
+
 // `buildQueryString` builds the query url with encoded parameters
 func (c *GatewayClient) buildQueryString(endpoint string, args map[string]string) string {
 	base, err := url.Parse(c.baseUrl)
@@ -42,17 +154,112 @@ func (c *GatewayClient) buildQueryString(endpoint string, args map[string]string
 	return base.String()
 }
 
-// get performs a "GET" http request with the given URL and returns the response body
-func (c *GatewayClient) get(queryUrl string) ([]byte, error) {
-	res, err := http.Get(queryUrl)
+// get performs a "GET" http request with the given URL and returns the response body.
+// Transport errors, 429 and 5xx responses are retried with exponential backoff,
+// honoring the Retry-After header when the gateway sends one, until ctx is cancelled.
+// When the retries run out the last error is returned as a [TransientError].
+func (c *GatewayClient) get(ctx context.Context, queryUrl string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryUrl, http.NoBody)
 	if err != nil {
 		return nil, err
-	} else if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
 	}
 
-	body, err := ioutil.ReadAll(res.Body)
-	return body, err
+	for attempt := 0; ; attempt++ {
+		var body []byte
+		var retryAfter time.Duration
+		body, retryAfter, err = c.do(req)
+		if err == nil || retryAfter < 0 {
+			return body, err
+		} else if attempt >= c.maxRetries {
+			return nil, &TransientError{Err: err}
+		}
+
+		wait := c.backoff(attempt)
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+		c.log.Warnw("Retrying failed gateway request", "url", queryUrl, "attempt", attempt+1,
+			"wait", wait, "err", err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// do sends a single request. A negative wait means the error must not be retried,
+// otherwise wait is the delay requested by the server, if any.
+func (c *GatewayClient) do(req *http.Request) ([]byte, time.Duration, error) {
+	endpoint := path.Base(req.URL.Path)
+	start := time.Now()
+	body, wait, err := c.send(req)
+	requestDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+	if err != nil {
+		requestErrors.WithLabelValues(endpoint).Inc()
+	}
+	return body, wait, err
+}
+
+func (c *GatewayClient) send(req *http.Request) ([]byte, time.Duration, error) {
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		body, err := io.ReadAll(res.Body)
+		return body, 0, err
+	}
+
+	// The gateway answers failed StarkNet queries with an error code, which
+	// is final even when sent with a 5xx status.
+	gatewayErr := new(Error)
+	if body, err := io.ReadAll(res.Body); err == nil && json.Unmarshal(body, gatewayErr) == nil && gatewayErr.Code != "" {
+		return nil, -1, gatewayErr
+	}
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError:
+		return nil, parseRetryAfter(res.Header.Get("Retry-After")), errors.New(res.Status)
+	default:
+		return nil, -1, errors.New(res.Status)
+	}
+}
+
+// backoff returns the delay before the given retry attempt: an exponentially
+// growing window capped at maxWait, from which a random "full jitter" delay is drawn.
+func (c *GatewayClient) backoff(attempt int) time.Duration {
+	wait := c.maxWait
+	if attempt < 32 {
+		if exp := c.minWait << attempt; exp > 0 && exp < c.maxWait {
+			wait = exp
+		}
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(wait))) + 1
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number
+// of seconds or an HTTP date. It returns 0 if the header is missing or malformed.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
 }
 
 // StateUpdate object returned by the gateway in JSON format for "get_state_update" endpoint
@@ -76,12 +283,22 @@ type StateUpdate struct {
 	} `json:"state_diff"`
 }
 
-func (c *GatewayClient) GetStateUpdate(blockNumber uint64) (*StateUpdate, error) {
+func (c *GatewayClient) GetStateUpdate(ctx context.Context, blockNumber uint64) (*StateUpdate, error) {
+	return c.getStateUpdate(ctx, strconv.FormatUint(blockNumber, 10))
+}
+
+// GetPendingStateUpdate gets the state update of the pending block. It has no block hash
+// and new root yet.
+func (c *GatewayClient) GetPendingStateUpdate(ctx context.Context) (*StateUpdate, error) {
+	return c.getStateUpdate(ctx, pendingBlockNumber)
+}
+
+func (c *GatewayClient) getStateUpdate(ctx context.Context, blockNumber string) (*StateUpdate, error) {
 	queryUrl := c.buildQueryString("get_state_update", map[string]string{
-		"blockNumber": strconv.FormatUint(blockNumber, 10),
+		"blockNumber": blockNumber,
 	})
 
-	if body, err := c.get(queryUrl); err != nil {
+	if body, err := c.get(ctx, queryUrl); err != nil {
 		return nil, err
 	} else {
 		update := new(StateUpdate)
@@ -120,12 +337,12 @@ type TransactionStatus struct {
 	Transaction      *Transaction `json:"transaction"`
 }
 
-func (c *GatewayClient) GetTransaction(transactionHash *felt.Felt) (*TransactionStatus, error) {
+func (c *GatewayClient) GetTransaction(ctx context.Context, transactionHash *felt.Felt) (*TransactionStatus, error) {
 	queryUrl := c.buildQueryString("get_transaction", map[string]string{
 		"transactionHash": "0x" + transactionHash.Text(16),
 	})
 
-	if body, err := c.get(queryUrl); err != nil {
+	if body, err := c.get(ctx, queryUrl); err != nil {
 		return nil, err
 	} else {
 		txStatus := new(TransactionStatus)
@@ -196,12 +413,34 @@ type Block struct {
 	SequencerAddress *felt.Felt            `json:"sequencer_address"`
 }
 
-func (c *GatewayClient) GetBlock(blockNumber uint64) (*Block, error) {
+const (
+	// pendingBlockNumber is the block number the gateway accepts to refer to the pending block.
+	pendingBlockNumber = "pending"
+	// latestBlockNumber is the block number the gateway accepts to refer to the latest block.
+	latestBlockNumber = "latest"
+)
+
+func (c *GatewayClient) GetBlock(ctx context.Context, blockNumber uint64) (*Block, error) {
+	return c.getBlock(ctx, strconv.FormatUint(blockNumber, 10))
+}
+
+// GetPendingBlock gets the block the sequencer is currently building. The pending block
+// has no hash, number and state root yet.
+func (c *GatewayClient) GetPendingBlock(ctx context.Context) (*Block, error) {
+	return c.getBlock(ctx, pendingBlockNumber)
+}
+
+// GetLatestBlock gets the latest block accepted by the sequencer.
+func (c *GatewayClient) GetLatestBlock(ctx context.Context) (*Block, error) {
+	return c.getBlock(ctx, latestBlockNumber)
+}
+
+func (c *GatewayClient) getBlock(ctx context.Context, blockNumber string) (*Block, error) {
 	queryUrl := c.buildQueryString("get_block", map[string]string{
-		"blockNumber": strconv.FormatUint(blockNumber, 10),
+		"blockNumber": blockNumber,
 	})
 
-	if body, err := c.get(queryUrl); err != nil {
+	if body, err := c.get(ctx, queryUrl); err != nil {
 		return nil, err
 	} else {
 		block := new(Block)
@@ -255,12 +494,12 @@ type ClassDefinition struct {
 	Program Program `json:"program"`
 }
 
-func (c *GatewayClient) GetClassDefinition(classHash *felt.Felt) (*ClassDefinition, error) {
+func (c *GatewayClient) GetClassDefinition(ctx context.Context, classHash *felt.Felt) (*ClassDefinition, error) {
 	queryUrl := c.buildQueryString("get_class_by_hash", map[string]string{
 		"classHash": "0x" + classHash.Text(16),
 	})
 
-	if body, err := c.get(queryUrl); err != nil {
+	if body, err := c.get(ctx, queryUrl); err != nil {
 		return nil, err
 	} else {
 		class := new(ClassDefinition)
@@ -1,15 +1,20 @@
 package clients
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStateUpdateUnmarshal(t *testing.T) {
@@ -391,6 +396,8 @@ func TestGetStateUpdate(t *testing.T) {
 	var update StateUpdate
 	err := json.Unmarshal(jsonData, &update)
 	assert.Equal(t, nil, err, "Unexpected error")
+	pendingUpdate := update
+	pendingUpdate.BlockHash, pendingUpdate.NewRoot = nil, nil
 
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -404,6 +411,10 @@ func TestGetStateUpdate(t *testing.T) {
 					w.WriteHeader(200)
 					marshedUpdate, _ := json.Marshal(update)
 					w.Write(marshedUpdate)
+				} else if queryBlockNumebr[0] == "pending" {
+					w.WriteHeader(200)
+					marshedUpdate, _ := json.Marshal(pendingUpdate)
+					w.Write(marshedUpdate)
 				} else {
 					w.WriteHeader(404)
 				}
@@ -414,15 +425,20 @@ func TestGetStateUpdate(t *testing.T) {
 	gatewayClient := NewGatewayClient(srv.URL)
 
 	t.Run("Test normal case", func(t *testing.T) {
-		stateUpdate, err := gatewayClient.GetStateUpdate(10)
+		stateUpdate, err := gatewayClient.GetStateUpdate(context.Background(), 10)
 		assert.Equal(t, nil, err, "Unexpected error")
 		assert.Equal(t, update, *stateUpdate)
 	})
 	t.Run("Test block number out of boundary", func(t *testing.T) {
-		stateUpdate, err := gatewayClient.GetStateUpdate(1000000)
+		stateUpdate, err := gatewayClient.GetStateUpdate(context.Background(), 1000000)
 		assert.Nil(t, stateUpdate, "Unexpected error")
 		assert.NotNil(t, err)
 	})
+	t.Run("Test pending state update", func(t *testing.T) {
+		stateUpdate, err := gatewayClient.GetPendingStateUpdate(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, pendingUpdate, *stateUpdate)
+	})
 }
 
 func TestGet(t *testing.T) {
@@ -438,14 +454,14 @@ func TestGet(t *testing.T) {
 	t.Run("Test normal get", func(t *testing.T) {
 		gatewayClient := NewGatewayClient(srv.URL)
 		expectPath := "/normal_get"
-		path, err := gatewayClient.get(srv.URL + expectPath)
+		path, err := gatewayClient.get(context.Background(), srv.URL+expectPath)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, expectPath, string(path))
 	})
 	t.Run("Test unnormal get", func(t *testing.T) {
 		gatewayClient := NewGatewayClient(srv.URL)
 		expectPath := "/unnormal_get"
-		path, err := gatewayClient.get("https\t://" + expectPath)
+		path, err := gatewayClient.get(context.Background(), "https\t://"+expectPath)
 		assert.Nil(t, path)
 		assert.NotNil(t, err)
 	})
@@ -507,14 +523,14 @@ func TestGetTransaction(t *testing.T) {
 	t.Run("Test normal case", func(t *testing.T) {
 		transaction_hash, _ := new(felt.Felt).SetString("0x00")
 		gatewayClient := NewGatewayClient(srv.URL)
-		actualStatus, err := gatewayClient.GetTransaction(transaction_hash)
+		actualStatus, err := gatewayClient.GetTransaction(context.Background(), transaction_hash)
 		assert.Equal(t, nil, err, "Unexpected error")
 		assert.Equal(t, *actualStatus, transactionStatus)
 	})
 	t.Run("Test case when transaction_hash not exit", func(t *testing.T) {
 		transaction_hash, _ := new(felt.Felt).SetString("0xffff")
 		gatewayClient := NewGatewayClient(srv.URL)
-		actualStatus, err := gatewayClient.GetTransaction(transaction_hash)
+		actualStatus, err := gatewayClient.GetTransaction(context.Background(), transaction_hash)
 		assert.Nil(t, actualStatus, "Unexpected error")
 		assert.NotNil(t, err)
 	})
@@ -532,6 +548,10 @@ func TestGetBlock(t *testing.T) {
 		t.Error(err)
 	}
 
+	pendingBlock := block
+	pendingBlock.Hash, pendingBlock.Number, pendingBlock.StateRoot = nil, 0, nil
+	pendingBlock.Status = "PENDING"
+
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case feederGatewayPath + "get_block":
@@ -540,10 +560,14 @@ func TestGetBlock(t *testing.T) {
 				assert.Equal(t, nil, err, "No Query value")
 				queryBlockNumebr := queryMap["blockNumber"]
 				t.Log(queryBlockNumebr[0])
-				if queryBlockNumebr[0] == "11817" {
+				if queryBlockNumebr[0] == "11817" || queryBlockNumebr[0] == "latest" {
 					w.WriteHeader(200)
 					marshaledStr, _ := json.Marshal(block)
 					w.Write(marshaledStr)
+				} else if queryBlockNumebr[0] == "pending" {
+					w.WriteHeader(200)
+					marshaledStr, _ := json.Marshal(pendingBlock)
+					w.Write(marshaledStr)
 				} else {
 					w.WriteHeader(404)
 				}
@@ -555,17 +579,27 @@ func TestGetBlock(t *testing.T) {
 
 	t.Run("Test normal case", func(t *testing.T) {
 		blcokNumber := uint64(11817)
-		actualBlock, err := gatewayClient.GetBlock(blcokNumber)
+		actualBlock, err := gatewayClient.GetBlock(context.Background(), blcokNumber)
 		assert.Equal(t, nil, err, "Unexpected error")
 		assert.Equal(t, *actualBlock, block)
 	})
 	t.Run("Test block number out of boundary", func(t *testing.T) {
 		blcokNumber := uint64(1000000)
 
-		actualBlock, err := gatewayClient.GetBlock(blcokNumber)
+		actualBlock, err := gatewayClient.GetBlock(context.Background(), blcokNumber)
 		assert.Nil(t, actualBlock, "Unexpected error")
 		assert.NotNil(t, err)
 	})
+	t.Run("Test pending block", func(t *testing.T) {
+		actualBlock, err := gatewayClient.GetPendingBlock(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, pendingBlock, *actualBlock)
+	})
+	t.Run("Test latest block", func(t *testing.T) {
+		actualBlock, err := gatewayClient.GetLatestBlock(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, block, *actualBlock)
+	})
 }
 
 func TestGetClassDefinition(t *testing.T) {
@@ -606,13 +640,13 @@ func TestGetClassDefinition(t *testing.T) {
 	t.Run("Test normal case", func(t *testing.T) {
 		classHash, _ := new(felt.Felt).SetString("0x01efa8f8")
 
-		actualClass, err := gatewayClient.GetClassDefinition(classHash)
+		actualClass, err := gatewayClient.GetClassDefinition(context.Background(), classHash)
 		assert.Equal(t, nil, err, "Unexpected error")
 		assert.Equal(t, *actualClass, class)
 	})
 	t.Run("Test classHash not find", func(t *testing.T) {
 		classHash, _ := new(felt.Felt).SetString("0x000")
-		actualClass, err := gatewayClient.GetClassDefinition(classHash)
+		actualClass, err := gatewayClient.GetClassDefinition(context.Background(), classHash)
 		assert.Nil(t, actualClass, "Unexpected error")
 		assert.NotNil(t, err)
 	})
@@ -623,25 +657,156 @@ func TestHttpError(t *testing.T) {
 		w.WriteHeader(http.StatusInternalServerError)
 	}))
 	defer srv.Close()
-	gatewayClient := NewGatewayClient(srv.URL)
+	gatewayClient := NewGatewayClient(srv.URL).WithBackoff(time.Millisecond, time.Millisecond)
 
 	t.Run("HTTP err in GetBlock", func(t *testing.T) {
-		_, err := gatewayClient.GetBlock(0)
+		_, err := gatewayClient.GetBlock(context.Background(), 0)
 		assert.EqualError(t, err, "500 Internal Server Error")
 	})
 
 	t.Run("HTTP err in GetTransaction", func(t *testing.T) {
-		_, err := gatewayClient.GetTransaction(new(felt.Felt))
+		_, err := gatewayClient.GetTransaction(context.Background(), new(felt.Felt))
 		assert.EqualError(t, err, "500 Internal Server Error")
 	})
 
 	t.Run("HTTP err in GetClassDefinition", func(t *testing.T) {
-		_, err := gatewayClient.GetClassDefinition(new(felt.Felt))
+		_, err := gatewayClient.GetClassDefinition(context.Background(), new(felt.Felt))
 		assert.EqualError(t, err, "500 Internal Server Error")
 	})
 
 	t.Run("HTTP err in GetStateUpdate", func(t *testing.T) {
-		_, err := gatewayClient.GetStateUpdate(0)
+		_, err := gatewayClient.GetStateUpdate(context.Background(), 0)
 		assert.EqualError(t, err, "500 Internal Server Error")
 	})
 }
+
+func TestGetRetries(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/flaky":
+			if requests < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("ok"))
+		case "/rate_limited":
+			if requests == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("ok"))
+		case "/slow":
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		case "/down":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	newClient := func() *GatewayClient {
+		requests = 0
+		return NewGatewayClient(srv.URL).WithBackoff(time.Millisecond, 5*time.Millisecond).WithMaxRetries(3)
+	}
+
+	t.Run("retry until success", func(t *testing.T) {
+		body, err := newClient().get(context.Background(), srv.URL+"/flaky")
+		require.NoError(t, err)
+		assert.Equal(t, "ok", string(body))
+		assert.Equal(t, 3, requests)
+	})
+
+	t.Run("honor Retry-After", func(t *testing.T) {
+		start := time.Now()
+		body, err := newClient().get(context.Background(), srv.URL+"/rate_limited")
+		require.NoError(t, err)
+		assert.Equal(t, "ok", string(body))
+		assert.Equal(t, 2, requests)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("give up after max retries", func(t *testing.T) {
+		errorsBefore := testutil.ToFloat64(requestErrors.WithLabelValues("down"))
+		body, err := newClient().get(context.Background(), srv.URL+"/down")
+		assert.Nil(t, body)
+		assert.EqualError(t, err, "502 Bad Gateway")
+		assert.True(t, IsTransient(err))
+		assert.Equal(t, 4, requests)
+		assert.Equal(t, errorsBefore+4, testutil.ToFloat64(requestErrors.WithLabelValues("down")))
+	})
+
+	t.Run("do not retry client errors", func(t *testing.T) {
+		body, err := newClient().get(context.Background(), srv.URL+"/missing")
+		assert.Nil(t, body)
+		assert.EqualError(t, err, "404 Not Found")
+		assert.False(t, IsTransient(err))
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		body, err := newClient().WithMaxRetries(0).WithTimeout(10*time.Millisecond).get(context.Background(), srv.URL+"/slow")
+		assert.Nil(t, body)
+		assert.True(t, IsTransient(err))
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)))
+
+	wait := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.Greater(t, wait, 50*time.Second)
+	assert.LessOrEqual(t, wait, time.Minute)
+}
+
+func TestGetCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	gatewayClient := NewGatewayClient(srv.URL).WithBackoff(time.Minute, time.Minute)
+
+	t.Run("cancel in-flight request", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		body, err := gatewayClient.get(ctx, srv.URL+"/slow")
+		assert.Nil(t, body)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("cancel while waiting to retry", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		body, err := gatewayClient.get(ctx, srv.URL+"/unavailable")
+		assert.Nil(t, body)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestGatewayError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"code": "StarknetErrorCode.BLOCK_NOT_FOUND", "message": "Block number 44 was not found."}`))
+	}))
+	defer srv.Close()
+	gatewayClient := NewGatewayClient(srv.URL).WithBackoff(time.Minute, time.Minute)
+
+	block, err := gatewayClient.GetBlock(context.Background(), 44)
+	assert.Nil(t, block)
+	assert.EqualError(t, err, "StarknetErrorCode.BLOCK_NOT_FOUND: Block number 44 was not found.")
+	assert.True(t, IsBlockNotFound(err))
+	assert.False(t, IsBlockNotFound(errors.New("404 Not Found")))
+}
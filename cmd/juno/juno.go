@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/NethermindEth/juno/node"
 	"github.com/NethermindEth/juno/utils"
@@ -23,32 +24,44 @@ Juno is a Go implementation of a StarkNet full node client created by Nethermind
 `
 
 const (
-	configF    = "config"
-	verbosityF = "verbosity"
-	rpcPortF   = "rpc-port"
-	metricsF   = "metrics"
-	dbPathF    = "db-path"
-	networkF   = "network"
-	ethNodeF   = "eth-node"
-
-	defaultConfig    = ""
-	defaultVerbosity = "info"
-	defaultRpcPort   = uint16(6060)
-	defaultMetrics   = false
-	defaultDbPath    = ""
-	defaultNetwork   = utils.GOERLI
-	defaultEthNode   = ""
+	configF          = "config"
+	verbosityF       = "verbosity"
+	logFormatF       = "log-format"
+	rpcPortF         = "rpc-port"
+	metricsF         = "metrics"
+	dbPathF          = "db-path"
+	networkF         = "network"
+	ethNodeF         = "eth-node"
+	ethPollIntervalF = "eth-poll-interval"
+	syncWorkersF     = "sync-workers"
+	pollIntervalF    = "poll-interval"
+
+	defaultConfig          = ""
+	defaultVerbosity       = "info"
+	defaultLogFormat       = utils.LogFormatConsole
+	defaultRpcPort         = uint16(6060)
+	defaultMetrics         = false
+	defaultDbPath          = ""
+	defaultNetwork         = utils.GOERLI
+	defaultEthNode         = ""
+	defaultEthPollInterval = time.Minute
+	defaultSyncWorkers     = uint(8)
+	defaultPollInterval    = 5 * time.Second
 
 	configFlagUsage    = "The yaml configuration file."
 	verbosityFlagUsage = "Verbosity of the logs. Options: debug, info, warn, error, dpanic, " +
 		"panic, fatal."
-	rpcPortUsage = "The port on which the RPC server will listen for requests. " +
+	logFormatUsage = "Format of the logs. Options: console, json."
+	rpcPortUsage   = "The port on which the RPC server will listen for requests. " +
 		"Warning: this exposes the node to external requests and potentially DoS attacks."
 	metricsUsage = "Enables the metrics server and listens on port 9090."
 	dbPathUsage  = "Location of the database files."
 	networkUsage = "Available StarkNet networks. Options: 0 = goerli and 1 = mainnet"
-	ethNodeUsage = "The Ethereum endpoint to synchronise with. " +
-		"If unset feeder gateway will be used."
+	ethNodeUsage = "The Ethereum endpoint used to verify the synced blocks against the state " +
+		"updates accepted on L1. If unset, blocks are not verified on L1."
+	ethPollIntervalUsage = "How long to wait before polling the Ethereum endpoint for new state updates."
+	syncWorkersUsage     = "The number of blocks fetched concurrently while syncing."
+	pollIntervalUsage    = "How long to wait before polling for a new block once the head of the chain is reached."
 )
 
 var (
@@ -64,11 +77,15 @@ func NewCmd(newNodeFn node.NewStarkNetNodeFn, quit <-chan os.Signal) *cobra.Comm
 
 	junoCmd.Flags().StringVar(&cfgFile, configF, defaultConfig, configFlagUsage)
 	junoCmd.Flags().String(verbosityF, defaultVerbosity, verbosityFlagUsage)
+	junoCmd.Flags().String(logFormatF, defaultLogFormat, logFormatUsage)
 	junoCmd.Flags().Uint16(rpcPortF, defaultRpcPort, rpcPortUsage)
 	junoCmd.Flags().Bool(metricsF, defaultMetrics, metricsUsage)
 	junoCmd.Flags().String(dbPathF, defaultDbPath, dbPathUsage)
 	junoCmd.Flags().Uint8(networkF, uint8(defaultNetwork), networkUsage)
 	junoCmd.Flags().String(ethNodeF, defaultEthNode, ethNodeUsage)
+	junoCmd.Flags().Duration(ethPollIntervalF, defaultEthPollInterval, ethPollIntervalUsage)
+	junoCmd.Flags().Uint(syncWorkersF, defaultSyncWorkers, syncWorkersUsage)
+	junoCmd.Flags().Duration(pollIntervalF, defaultPollInterval, pollIntervalUsage)
 
 	junoCmd.RunE = func(cmd *cobra.Command, _ []string) error {
 		v := viper.New()
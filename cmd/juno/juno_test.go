@@ -78,11 +78,15 @@ Juno is a Go implementation of a StarkNet full node client created by Nethermind
 		// checks on the config, those will be checked by the StarkNetNode
 		// implementation.
 		defaultVerbosity := "info"
+		defaultLogFormat := "console"
 		defaultRpcPort := uint16(6060)
 		defaultMetrics := false
 		defaultDbPath := ""
 		defaultNetwork := utils.GOERLI
 		defaultEthNode := ""
+		defaultEthPollInterval := time.Minute
+		defaultSyncWorkers := uint(8)
+		defaultPollInterval := 5 * time.Second
 
 		tests := map[string]struct {
 			cfgFile         func(t *testing.T, cfg string) (string, func())
@@ -95,20 +99,28 @@ Juno is a Go implementation of a StarkNet full node client created by Nethermind
 				inputArgs: []string{""},
 				expectedConfig: &node.Config{
 					Verbosity:    defaultVerbosity,
+					LogFormat:    defaultLogFormat,
 					RpcPort:      defaultRpcPort,
 					Metrics:      defaultMetrics,
 					DatabasePath: defaultDbPath,
 					Network:      defaultNetwork, EthNode: defaultEthNode,
+					SyncWorkers:     defaultSyncWorkers,
+					PollInterval:    defaultPollInterval,
+					EthPollInterval: defaultEthPollInterval,
 				},
 			},
 			"config file path is empty string": {
 				inputArgs: []string{"--config", ""},
 				expectedConfig: &node.Config{
 					Verbosity:    defaultVerbosity,
+					LogFormat:    defaultLogFormat,
 					RpcPort:      defaultRpcPort,
 					Metrics:      defaultMetrics,
 					DatabasePath: defaultDbPath,
 					Network:      defaultNetwork, EthNode: defaultEthNode,
+					SyncWorkers:     defaultSyncWorkers,
+					PollInterval:    defaultPollInterval,
+					EthPollInterval: defaultEthPollInterval,
 				},
 			},
 			"config file doesn't exist": {
@@ -120,27 +132,39 @@ Juno is a Go implementation of a StarkNet full node client created by Nethermind
 				cfgFileContents: "\n",
 				expectedConfig: &node.Config{
 					Verbosity: defaultVerbosity,
+					LogFormat: defaultLogFormat,
 					RpcPort:   defaultRpcPort,
 					Metrics:   defaultMetrics,
 					Network:   defaultNetwork, EthNode: defaultEthNode,
+					SyncWorkers:     defaultSyncWorkers,
+					PollInterval:    defaultPollInterval,
+					EthPollInterval: defaultEthPollInterval,
 				},
 			},
 			"config file with all settings but without any other flags": {
 				cfgFile: tempCfgFile,
 				cfgFileContents: `verbosity: "debug"
+log-format: json
 rpc-port: 4576
 metrics: true
 db-path: /home/.juno
 network: 1
 eth-node: "https://some-ethnode:5673"
+eth-poll-interval: 30s
+sync-workers: 2
+poll-interval: 2s
 `,
 				expectedConfig: &node.Config{
-					Verbosity:    "debug",
-					RpcPort:      4576,
-					Metrics:      true,
-					DatabasePath: "/home/.juno",
-					Network:      utils.MAINNET,
-					EthNode:      "https://some-ethnode:5673",
+					Verbosity:       "debug",
+					LogFormat:       "json",
+					RpcPort:         4576,
+					Metrics:         true,
+					DatabasePath:    "/home/.juno",
+					Network:         utils.MAINNET,
+					EthNode:         "https://some-ethnode:5673",
+					SyncWorkers:     2,
+					PollInterval:    2 * time.Second,
+					EthPollInterval: 30 * time.Second,
 				},
 			},
 			"config file with some settings but without any other flags": {
@@ -150,27 +174,36 @@ rpc-port: 4576
 metrics: true
 `,
 				expectedConfig: &node.Config{
-					Verbosity:    "debug",
-					RpcPort:      4576,
-					Metrics:      true,
-					DatabasePath: defaultDbPath,
-					Network:      defaultNetwork,
-					EthNode:      defaultEthNode,
+					Verbosity:       "debug",
+					LogFormat:       defaultLogFormat,
+					RpcPort:         4576,
+					Metrics:         true,
+					DatabasePath:    defaultDbPath,
+					Network:         defaultNetwork,
+					EthNode:         defaultEthNode,
+					SyncWorkers:     defaultSyncWorkers,
+					PollInterval:    defaultPollInterval,
+					EthPollInterval: defaultEthPollInterval,
 				},
 			},
 			"all flags without config file": {
 				inputArgs: []string{
 					"--verbosity", "debug", "--rpc-port", "4576",
 					"--metrics", "--db-path", "/home/.juno", "--network", "1",
-					"--eth-node", "https://some-ethnode:5673",
+					"--eth-node", "https://some-ethnode:5673", "--sync-workers", "4",
+					"--poll-interval", "1m", "--log-format", "json", "--eth-poll-interval", "2m",
 				},
 				expectedConfig: &node.Config{
-					Verbosity:    "debug",
-					RpcPort:      4576,
-					Metrics:      true,
-					DatabasePath: "/home/.juno",
-					Network:      utils.MAINNET,
-					EthNode:      "https://some-ethnode:5673",
+					Verbosity:       "debug",
+					LogFormat:       "json",
+					RpcPort:         4576,
+					Metrics:         true,
+					DatabasePath:    "/home/.juno",
+					Network:         utils.MAINNET,
+					EthNode:         "https://some-ethnode:5673",
+					SyncWorkers:     4,
+					PollInterval:    time.Minute,
+					EthPollInterval: 2 * time.Minute,
 				},
 			},
 			"some flags without config file": {
@@ -179,12 +212,16 @@ metrics: true
 					"--network", "1",
 				},
 				expectedConfig: &node.Config{
-					Verbosity:    "debug",
-					RpcPort:      4576,
-					Metrics:      defaultMetrics,
-					DatabasePath: "/home/.juno",
-					Network:      utils.MAINNET,
-					EthNode:      defaultEthNode,
+					Verbosity:       "debug",
+					LogFormat:       defaultLogFormat,
+					RpcPort:         4576,
+					Metrics:         defaultMetrics,
+					DatabasePath:    "/home/.juno",
+					Network:         utils.MAINNET,
+					EthNode:         defaultEthNode,
+					SyncWorkers:     defaultSyncWorkers,
+					PollInterval:    defaultPollInterval,
+					EthPollInterval: defaultEthPollInterval,
 				},
 			},
 			"all setting set in both config file and flags": {
@@ -202,12 +239,16 @@ eth-node: "https://some-ethnode:5673"
 					"--eth-node", "https://some-ethnode:5674",
 				},
 				expectedConfig: &node.Config{
-					Verbosity:    "error",
-					RpcPort:      4577,
-					Metrics:      true,
-					DatabasePath: "/home/flag/.juno",
-					Network:      utils.MAINNET,
-					EthNode:      "https://some-ethnode:5674",
+					Verbosity:       "error",
+					LogFormat:       defaultLogFormat,
+					RpcPort:         4577,
+					Metrics:         true,
+					DatabasePath:    "/home/flag/.juno",
+					Network:         utils.MAINNET,
+					EthNode:         "https://some-ethnode:5674",
+					SyncWorkers:     defaultSyncWorkers,
+					PollInterval:    defaultPollInterval,
+					EthPollInterval: defaultEthPollInterval,
 				},
 			},
 			"some setting set in both config file and flags": {
@@ -221,12 +262,16 @@ network: 1
 					"https://some-ethnode:5674",
 				},
 				expectedConfig: &node.Config{
-					Verbosity:    "panic",
-					RpcPort:      4576,
-					Metrics:      true,
-					DatabasePath: "/home/flag/.juno",
-					Network:      utils.MAINNET,
-					EthNode:      "https://some-ethnode:5674",
+					Verbosity:       "panic",
+					LogFormat:       defaultLogFormat,
+					RpcPort:         4576,
+					Metrics:         true,
+					DatabasePath:    "/home/flag/.juno",
+					Network:         utils.MAINNET,
+					EthNode:         "https://some-ethnode:5674",
+					SyncWorkers:     defaultSyncWorkers,
+					PollInterval:    defaultPollInterval,
+					EthPollInterval: defaultEthPollInterval,
 				},
 			},
 			"some setting set in default, config file and flags": {
@@ -237,12 +282,16 @@ network: 1
 					"https://some-ethnode:5674",
 				},
 				expectedConfig: &node.Config{
-					Verbosity:    defaultVerbosity,
-					RpcPort:      defaultRpcPort,
-					Metrics:      true,
-					DatabasePath: "/home/flag/.juno",
-					Network:      utils.MAINNET,
-					EthNode:      "https://some-ethnode:5674",
+					Verbosity:       defaultVerbosity,
+					LogFormat:       defaultLogFormat,
+					RpcPort:         defaultRpcPort,
+					Metrics:         true,
+					DatabasePath:    "/home/flag/.juno",
+					Network:         utils.MAINNET,
+					EthNode:         "https://some-ethnode:5674",
+					SyncWorkers:     defaultSyncWorkers,
+					PollInterval:    defaultPollInterval,
+					EthPollInterval: defaultEthPollInterval,
 				},
 			},
 		}
@@ -17,6 +17,36 @@ func (e ErrUnverifiableBlock) Error() string {
 	return fmt.Sprintf("block is unverifiable: %d", e.blockNumber)
 }
 
+// BlockStatus is the finality status of a block.
+type BlockStatus uint8
+
+const (
+	StatusPending BlockStatus = iota
+	StatusAcceptedOnL2
+	StatusAcceptedOnL1
+	StatusRejected
+	// StatusUnknown is the status of blocks whose status reported by the sequencer is not
+	// one of the above.
+	StatusUnknown
+)
+
+func (s BlockStatus) String() string {
+	switch s {
+	case StatusPending:
+		return "PENDING"
+	case StatusAcceptedOnL2:
+		return "ACCEPTED_ON_L2"
+	case StatusAcceptedOnL1:
+		return "ACCEPTED_ON_L1"
+	case StatusRejected:
+		return "REJECTED"
+	case StatusUnknown:
+		return "UNKNOWN"
+	default:
+		return ""
+	}
+}
+
 type Block struct {
 	// The hash of this block
 	Hash *felt.Felt
@@ -30,7 +60,6 @@ type Block struct {
 	SequencerAddress *felt.Felt
 	// The time the sequencer created this block before executing transactions
 	Timestamp *felt.Felt
-	// TODO: Add Transactions and TransactionReceipts
 	// TODO: Remove TransactionCount and EventCount
 	// The number of transactions in a block
 	TransactionCount *felt.Felt
@@ -45,6 +74,12 @@ type Block struct {
 	ProtocolVersion *felt.Felt
 	// Extraneous data that might be useful for running transactions
 	ExtraData *felt.Felt
+	// The transactions included in this block
+	Transactions []Transaction
+	// The receipts of the transactions included in this block, in the same order
+	Receipts []*TransactionReceipt
+	// The finality status of this block, it changes over time as the block gets accepted on L1
+	Status BlockStatus
 }
 
 type blockHashMetaInfo struct {
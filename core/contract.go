@@ -95,6 +95,22 @@ func (c *Contract) Deploy(classHash *felt.Felt) error {
 	return nil
 }
 
+// Purge removes the contract from the database. It is used to undo
+// [Contract.Deploy] and expects the contract storage to be empty already.
+func (c *Contract) Purge() error {
+	addrBytes := c.Address.Marshal()
+	for _, key := range [][]byte{
+		db.ContractClassHash.Key(addrBytes),
+		db.ContractNonce.Key(addrBytes),
+		db.ContractRootKey.Key(addrBytes),
+	} {
+		if err := c.txn.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Nonce returns the number of transactions sent from this contract.
 // Only account contracts can have a non-zero nonce.
 func (c *Contract) Nonce() (*felt.Felt, error) {
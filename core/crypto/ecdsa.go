@@ -0,0 +1,200 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fr"
+)
+
+// ecdsaBits is the number of bits of the message hash, r and w values accepted by the
+// StarkNet flavour of ECDSA.
+const ecdsaBits = 251
+
+var (
+	// curveOrder is the order of the group generated by the generator of the STARK curve.
+	curveOrder = fr.Modulus()
+	// ecdsaBound is the exclusive upper bound of the message hash, r and w values.
+	ecdsaBound = new(big.Int).Lsh(big.NewInt(1), ecdsaBits)
+	// curveBeta is the b coefficient of the STARK curve y^2 = x^3 + x + b.
+	curveBeta fp.Element
+)
+
+func init() {
+	curveBeta.SetString("3141592653589793238462643383279502884197169399375105820974944592307816406665")
+}
+
+// PublicKey returns the public key of privateKey, which is the x coordinate of the
+// point privateKey * G on the STARK curve.
+func PublicKey(privateKey *felt.Felt) *felt.Felt {
+	var point starkcurve.G1Affine
+	point.ScalarMultiplicationBase(privateKey.BigInt(new(big.Int)))
+	return felt.NewFelt(&point.X)
+}
+
+// Verify reports whether (r, s) is a valid signature of msgHash for publicKey, as defined by
+// the [reference implementation]. As StarkNet accounts only store the x coordinate of their
+// public key, both points sharing this x coordinate are accepted.
+//
+// [reference implementation]: https://github.com/starkware-libs/cairo-lang/blob/de741b92657f245a50caab99cfaef093152fd8be/src/starkware/crypto/signature/signature.py
+func Verify(publicKey, msgHash, r, s *felt.Felt) bool {
+	rInt, sInt, z := r.BigInt(new(big.Int)), s.BigInt(new(big.Int)), msgHash.BigInt(new(big.Int))
+	if rInt.Sign() <= 0 || rInt.Cmp(ecdsaBound) >= 0 ||
+		sInt.Sign() <= 0 || sInt.Cmp(curveOrder) >= 0 ||
+		z.Cmp(ecdsaBound) >= 0 {
+		return false
+	}
+
+	w := new(big.Int).ModInverse(sInt, curveOrder)
+	if w.Cmp(ecdsaBound) >= 0 {
+		return false
+	}
+
+	q, ok := pointFromX(publicKey.Impl())
+	if !ok {
+		return false
+	}
+
+	var zG, rQ starkcurve.G1Jac
+	zG.FromAffine(new(starkcurve.G1Affine).ScalarMultiplicationBase(z))
+	rQ.ScalarMultiplicationAffine(q, rInt)
+
+	for _, sum := range []*starkcurve.G1Jac{
+		new(starkcurve.G1Jac).Set(&zG).AddAssign(&rQ),
+		new(starkcurve.G1Jac).Set(&zG).SubAssign(&rQ),
+	} {
+		var wB starkcurve.G1Affine
+		wB.FromJacobian(new(starkcurve.G1Jac).ScalarMultiplication(sum, w))
+		if felt.NewFelt(&wB.X).Equal(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign signs msgHash with privateKey as the [reference implementation] does, so that the
+// same signature is produced for the same message hash and key.
+//
+// [reference implementation]: https://github.com/starkware-libs/cairo-lang/blob/de741b92657f245a50caab99cfaef093152fd8be/src/starkware/crypto/signature/signature.py
+func Sign(privateKey, msgHash *felt.Felt) (*felt.Felt, *felt.Felt, error) {
+	key, z := privateKey.BigInt(new(big.Int)), msgHash.BigInt(new(big.Int))
+	if key.Sign() <= 0 || key.Cmp(curveOrder) >= 0 {
+		return nil, nil, errors.New("private key out of range")
+	}
+	if z.Cmp(ecdsaBound) >= 0 {
+		return nil, nil, errors.New("message hash out of range")
+	}
+
+	var seed []byte
+	for i := int64(1); ; i++ {
+		k := generateK(z, key, seed)
+		// a new k is generated with the next seed if this one is rejected
+		seed = big.NewInt(i).Bytes()
+
+		var kG starkcurve.G1Affine
+		kG.ScalarMultiplicationBase(k)
+		r := kG.X.BigInt(new(big.Int))
+		if r.Sign() == 0 || r.Cmp(ecdsaBound) >= 0 {
+			continue
+		}
+
+		// w = k / (z + r * key) mod n
+		sum := new(big.Int).Mul(r, key)
+		sum.Add(sum, z).Mod(sum, curveOrder)
+		if sum.Sign() == 0 {
+			continue
+		}
+		w := sum.ModInverse(sum, curveOrder)
+		w.Mul(w, k).Mod(w, curveOrder)
+		if w.Sign() == 0 || w.Cmp(ecdsaBound) >= 0 {
+			continue
+		}
+
+		s := w.ModInverse(w, curveOrder)
+		return new(felt.Felt).SetBytes(r.Bytes()), new(felt.Felt).SetBytes(s.Bytes()), nil
+	}
+}
+
+// pointFromX returns one of the points of the STARK curve with the given x coordinate.
+func pointFromX(x *fp.Element) (*starkcurve.G1Affine, bool) {
+	// y^2 = x^3 + x + b
+	var y fp.Element
+	y.Square(x).Mul(&y, x).Add(&y, x).Add(&y, &curveBeta)
+	if y.Sqrt(&y) == nil {
+		return nil, false
+	}
+	return &starkcurve.G1Affine{X: *x, Y: y}, true
+}
+
+// generateK deterministically derives the nonce of a signature from the message hash and the
+// private key following [RFC 6979] with SHA-256, as the python-ecdsa library used by the
+// reference implementation does. seed is the optional additional data of section 3.6.
+//
+// [RFC 6979]: https://www.rfc-editor.org/rfc/rfc6979#section-3.2
+func generateK(msgHash, privateKey *big.Int, seed []byte) *big.Int {
+	qlen := curveOrder.BitLen()
+	rlen := (qlen + 7) / 8
+
+	// The reference implementation pads message hashes which are one nibble short of
+	// 252 bits, for consistency with elliptic.js.
+	z := new(big.Int).Set(msgHash)
+	if bits := z.BitLen(); bits >= 248 && bits%8 >= 1 && bits%8 <= 4 {
+		z.Lsh(z, 4)
+	}
+
+	// bits2octets(h1) = int2octets(bits2int(h1) mod q)
+	z1 := bits2int(z.Bytes(), qlen)
+	if z1.Cmp(curveOrder) >= 0 {
+		z1.Sub(z1, curveOrder)
+	}
+	data := make([]byte, 0, 2*rlen+len(seed))
+	data = append(data, privateKey.FillBytes(make([]byte, rlen))...)
+	data = append(data, z1.FillBytes(make([]byte, rlen))...)
+	data = append(data, seed...)
+
+	mac := func(key []byte, parts ...[]byte) []byte {
+		m := hmac.New(sha256.New, key)
+		for _, part := range parts {
+			m.Write(part)
+		}
+		return m.Sum(nil)
+	}
+
+	v := make([]byte, sha256.Size)
+	for i := range v {
+		v[i] = 0x01
+	}
+	k := make([]byte, sha256.Size)
+	k = mac(k, v, []byte{0x00}, data)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, data)
+	v = mac(k, v)
+
+	for {
+		var t []byte
+		for len(t) < rlen {
+			v = mac(k, v)
+			t = append(t, v...)
+		}
+
+		if secret := bits2int(t, qlen); secret.Sign() > 0 && secret.Cmp(curveOrder) < 0 {
+			return secret
+		}
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+}
+
+// bits2int converts the leftmost qlen bits of b to an integer, see section 2.3.2 of RFC 6979.
+func bits2int(b []byte, qlen int) *big.Int {
+	x := new(big.Int).SetBytes(b)
+	if blen := 8 * len(b); blen > qlen {
+		x.Rsh(x, uint(blen-qlen))
+	}
+	return x
+}
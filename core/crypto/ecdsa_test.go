@@ -0,0 +1,111 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func feltFromString(t *testing.T, s string) *felt.Felt {
+	t.Helper()
+	f, err := new(felt.Felt).SetString(s)
+	require.NoError(t, err)
+	return f
+}
+
+func TestPublicKey(t *testing.T) {
+	privateKey := feltFromString(t, "104397037759416840641267745129360920341912682966983343798870479003077644689")
+	want := feltFromString(t, "1913222325711601599563860015182907040361852177892954047964358042507353067365")
+	assert.Equal(t, want, PublicKey(privateKey))
+}
+
+func TestVerify(t *testing.T) {
+	// Test vectors from cairo-lang, signed by the reference implementation.
+	tests := map[string]struct {
+		publicKey, msgHash, r, s string
+	}{
+		"key derived from private key": {
+			publicKey: "1913222325711601599563860015182907040361852177892954047964358042507353067365",
+			msgHash:   "2680576269831035412725132645807649347045997097070150916157159360688041452746",
+			r:         "607684330780324271206686790958794501662789535258258105407533051445036595885",
+			s:         "453590782387078613313238308551260565642934039343903827708036287031471258875",
+		},
+		"key with the other y coordinate": {
+			publicKey: "0x33f45f07e1bd1a51b45fc24ec8c8c9908db9e42191be9e169bfcac0c0d99745",
+			msgHash:   "0x7f15c38ea577a26f4f553282fcfe4f1feeb8ecfaad8f221ae41abf8224cbddd",
+			r:         "2458502865976494910213617956670505342647705497324144349552978333078363662855",
+			s:         "3439514492576562277095748549117516048613512930236865921315982886313695689433",
+		},
+		"account public key": {
+			publicKey: "0x4e52f2f40700e9cdd0f386c31a1f160d0f310504fc508a1051b747a26070d10",
+			msgHash:   "0x324df642fcc7d98b1d9941250840704f35b9ac2e3e2b58b6a034cc09adac54c",
+			r:         "2849277527182985104629156126825776904262411756563556603659114084811678482647",
+			s:         "3156340738553451171391693475354397094160428600037567299774561739201502791079",
+		},
+	}
+
+	one := new(felt.Felt).SetUint64(1)
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			publicKey, msgHash := feltFromString(t, test.publicKey), feltFromString(t, test.msgHash)
+			r, s := feltFromString(t, test.r), feltFromString(t, test.s)
+
+			assert.True(t, Verify(publicKey, msgHash, r, s))
+
+			assert.False(t, Verify(new(felt.Felt).Add(publicKey, one), msgHash, r, s))
+			assert.False(t, Verify(publicKey, new(felt.Felt).Add(msgHash, one), r, s))
+			assert.False(t, Verify(publicKey, msgHash, new(felt.Felt).Add(r, one), s))
+			assert.False(t, Verify(publicKey, msgHash, r, new(felt.Felt).Add(s, one)))
+		})
+	}
+
+	t.Run("out of range", func(t *testing.T) {
+		test := tests["account public key"]
+		publicKey, msgHash := feltFromString(t, test.publicKey), feltFromString(t, test.msgHash)
+		r, s := feltFromString(t, test.r), feltFromString(t, test.s)
+		tooBig := feltFromString(t, "0x800000000000000000000000000000000000000000000000000000000000000")
+
+		assert.False(t, Verify(publicKey, msgHash, new(felt.Felt), s))
+		assert.False(t, Verify(publicKey, msgHash, r, new(felt.Felt)))
+		assert.False(t, Verify(publicKey, msgHash, tooBig, s))
+		assert.False(t, Verify(publicKey, tooBig, r, s))
+	})
+}
+
+func TestSign(t *testing.T) {
+	privateKey := feltFromString(t, "104397037759416840641267745129360920341912682966983343798870479003077644689")
+	msgHash := feltFromString(t, "2680576269831035412725132645807649347045997097070150916157159360688041452746")
+
+	r, s, err := Sign(privateKey, msgHash)
+	require.NoError(t, err)
+	assert.Equal(t, feltFromString(t, "607684330780324271206686790958794501662789535258258105407533051445036595885"), r)
+	assert.Equal(t, feltFromString(t, "453590782387078613313238308551260565642934039343903827708036287031471258875"), s)
+
+	t.Run("round trip", func(t *testing.T) {
+		// 248 bit values are valid private keys and message hashes
+		var keyBytes, hashBytes [31]byte
+		for i := 0; i < 10; i++ {
+			_, err := rand.Read(keyBytes[:])
+			require.NoError(t, err)
+			_, err = rand.Read(hashBytes[:])
+			require.NoError(t, err)
+			key, hash := new(felt.Felt).SetBytes(keyBytes[:]), new(felt.Felt).SetBytes(hashBytes[:])
+
+			r, s, err := Sign(key, hash)
+			require.NoError(t, err)
+			assert.True(t, Verify(PublicKey(key), hash, r, s))
+		}
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, _, err := Sign(new(felt.Felt), msgHash)
+		assert.EqualError(t, err, "private key out of range")
+
+		tooBig := feltFromString(t, "0x800000000000000000000000000000000000000000000000000000000000000")
+		_, _, err = Sign(privateKey, tooBig)
+		assert.EqualError(t, err, "message hash out of range")
+	})
+}
@@ -1,16 +1,28 @@
 package crypto
 
 import (
+	"hash"
+	"sync"
+
 	"github.com/NethermindEth/juno/core/felt"
 	"golang.org/x/crypto/sha3"
 )
 
-var h = sha3.NewLegacyKeccak256()
+// keccakPool holds keccak hashers for reuse, so that StarkNetKeccak is safe for concurrent
+// use without allocating a hasher on every call.
+var keccakPool = sync.Pool{
+	New: func() any {
+		return sha3.NewLegacyKeccak256()
+	},
+}
 
-// StarkNetKeccak implements [StarkNet keccak]
+// StarkNetKeccak implements [StarkNet keccak]. It is safe for concurrent use.
 //
 // [StarkNet keccak]: https://docs.starknet.io/documentation/develop/Hashing/hash-functions/#starknet_keccak
 func StarkNetKeccak(b []byte) (*felt.Felt, error) {
+	h := keccakPool.Get().(hash.Hash)
+	defer keccakPool.Put(h)
+
 	h.Reset()
 	_, err := h.Write(b)
 	if err != nil {
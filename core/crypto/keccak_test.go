@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 )
 
@@ -26,3 +27,36 @@ func TestStarkNetKeccak(t *testing.T) {
 		}
 	}
 }
+
+func TestStarkNetKeccakConcurrent(t *testing.T) {
+	inputs := map[string]string{}
+	for _, input := range []string{"", "abc", "test", "starknet", "keccak"} {
+		d, err := StarkNetKeccak([]byte(input))
+		if err != nil {
+			t.Fatalf("expected no error but got %s", err)
+		}
+		inputs[input] = fmt.Sprintf("%x", d.Bytes())
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				for input, want := range inputs {
+					d, err := StarkNetKeccak([]byte(input))
+					if err != nil {
+						t.Errorf("expected no error but got %s", err)
+						return
+					}
+					if got := fmt.Sprintf("%x", d.Bytes()); got != want {
+						t.Errorf("expected hash for \"%s\" = %q but got %q", input, want, got)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
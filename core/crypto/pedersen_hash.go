@@ -1,8 +1,6 @@
 package crypto
 
 import (
-	"math/big"
-
 	"github.com/NethermindEth/juno/core/felt"
 	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
@@ -43,6 +41,8 @@ func init() {
 	x.SetString("2379962749567351885752724891227938183011949129833673362440656643086021394946")
 	y.SetString("776496453633298175483985398648758586525933812536653089401905292063708816422")
 	p3 = new(starkcurve.G1Jac).FromAffine(&starkcurve.G1Affine{X: *x, Y: *y})
+
+	precompute()
 }
 
 // PedersenArray implements [Pedersen array hashing].
@@ -62,24 +62,9 @@ func PedersenArray(elems ...*felt.Felt) *felt.Felt {
 // [reference implementation]: https://github.com/starkware-libs/cairo-lang/blob/de741b92657f245a50caab99cfaef093152fd8be/src/starkware/crypto/signature/fast_pedersen_hash.py
 func Pedersen(a *felt.Felt, b *felt.Felt) *felt.Felt {
 	result := new(starkcurve.G1Jac).Set(shiftPoint)
-	result.AddAssign(processElement(a.Impl(), p0, p1))
-	result.AddAssign(processElement(b.Impl(), p2, p3))
+	addElements(result, a, b)
 
 	var affine starkcurve.G1Affine
 	affine.FromJacobian(result)
 	return felt.NewFelt(&affine.X)
 }
-
-func processElement(a *fp.Element, p1 *starkcurve.G1Jac, p2 *starkcurve.G1Jac) *starkcurve.G1Jac {
-	var bigInt big.Int
-	var aBytes [32]byte
-	a.BigInt(&bigInt).FillBytes(aBytes[:])
-
-	highPart := bigInt.SetUint64(uint64(aBytes[0])) // The top nibble (bits 249-252)
-	lowPart := aBytes[1:]                           // Zero-out the top nibble (bits 249-252)
-
-	m := new(starkcurve.G1Jac).ScalarMultiplication(p2, highPart)
-	var n starkcurve.G1Jac
-	n.ScalarMultiplication(p1, bigInt.SetBytes(lowPart))
-	return m.AddAssign(&n)
-}
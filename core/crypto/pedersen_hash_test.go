@@ -117,6 +117,9 @@ func TestPedersenArray(t *testing.T) {
 var feltBench *felt.Felt
 
 // go test -bench=. -run=^# -cpu=1,2,4,8,16
+//
+// Add -tags nopedersentable to measure the implementation without precomputed tables,
+// BenchmarkPointTable compares both ways of computing the multiples of the points.
 func BenchmarkPedersenArray(b *testing.B) {
 	numOfElems := []int{3, 5, 10, 15, 20, 25, 30, 35, 40}
 	createRandomFelts := func(n int) []*felt.Felt {
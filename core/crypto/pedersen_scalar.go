@@ -0,0 +1,36 @@
+//go:build nopedersentable
+
+package crypto
+
+import (
+	"math/big"
+
+	"github.com/NethermindEth/juno/core/felt"
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+// precompute is a no-op, the points are multiplied on every call when built with the
+// nopedersentable tag.
+func precompute() {}
+
+// addElements adds the contributions of a and b to the Pedersen hash to result by scalar
+// multiplication of the constant points.
+func addElements(result *starkcurve.G1Jac, a, b *felt.Felt) {
+	result.AddAssign(processElement(a.Impl(), p0, p1))
+	result.AddAssign(processElement(b.Impl(), p2, p3))
+}
+
+func processElement(a *fp.Element, p1 *starkcurve.G1Jac, p2 *starkcurve.G1Jac) *starkcurve.G1Jac {
+	var bigInt big.Int
+	var aBytes [32]byte
+	a.BigInt(&bigInt).FillBytes(aBytes[:])
+
+	highPart := bigInt.SetUint64(uint64(aBytes[0])) // The top nibble (bits 249-252)
+	lowPart := aBytes[1:]                           // Zero-out the top nibble (bits 249-252)
+
+	m := new(starkcurve.G1Jac).ScalarMultiplication(p2, highPart)
+	var n starkcurve.G1Jac
+	n.ScalarMultiplication(p1, bigInt.SetBytes(lowPart))
+	return m.AddAssign(&n)
+}
@@ -0,0 +1,64 @@
+//go:build !nopedersentable
+
+package crypto
+
+import (
+	"github.com/NethermindEth/juno/core/felt"
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
+)
+
+// pointTable holds the multiples of a point needed to multiply it by any scalar of up to
+// len(pointTable) bytes with additions only: pointTable[i][d-1] = d * 256^i * P, where i
+// counts the bytes from the least significant one. This is the approach of cairo-lang's
+// fast_pedersen_hash, with one byte instead of one bit per table row.
+type pointTable [][255]starkcurve.G1Affine
+
+// Tables of the constant points. The low 248 bits of an element are multiplied with p0 and
+// p2, the top nibble (bits 249-252) with p1 and p3.
+var p0Table, p1Table, p2Table, p3Table pointTable
+
+// precompute fills the tables of the constant points, which takes about 1MB of memory.
+// Build with the nopedersentable tag to use scalar multiplication instead.
+func precompute() {
+	p0Table = newPointTable(p0, 31)
+	p1Table = newPointTable(p1, 1)
+	p2Table = newPointTable(p2, 31)
+	p3Table = newPointTable(p3, 1)
+}
+
+func newPointTable(p *starkcurve.G1Jac, size int) pointTable {
+	table := make(pointTable, size)
+	multiples := make([]starkcurve.G1Jac, len(table[0]))
+	base := new(starkcurve.G1Jac).Set(p)
+	for i := range table {
+		multiples[0].Set(base)
+		for d := 1; d < len(multiples); d++ {
+			multiples[d].Set(&multiples[d-1]).AddAssign(base)
+		}
+		copy(table[i][:], starkcurve.BatchJacobianToAffineG1(multiples))
+		// base = 256 * base
+		base.AddAssign(&multiples[len(multiples)-1])
+	}
+	return table
+}
+
+// addElements adds the contributions of a and b to the Pedersen hash to result.
+func addElements(result *starkcurve.G1Jac, a, b *felt.Felt) {
+	addElement(result, a, p0Table, p1Table)
+	addElement(result, b, p2Table, p3Table)
+}
+
+func addElement(result *starkcurve.G1Jac, a *felt.Felt, low, high pointTable) {
+	aBytes := a.Bytes()
+	high.add(result, aBytes[:1]) // The top nibble (bits 249-252)
+	low.add(result, aBytes[1:])
+}
+
+// add adds scalar * P to result, with scalar given in big-endian bytes.
+func (t pointTable) add(result *starkcurve.G1Jac, scalar []byte) {
+	for i, digit := range scalar {
+		if digit != 0 {
+			result.AddMixed(&t[len(scalar)-1-i][digit-1])
+		}
+	}
+}
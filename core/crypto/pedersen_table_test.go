@@ -0,0 +1,59 @@
+//go:build !nopedersentable
+
+package crypto
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointTable(t *testing.T) {
+	scalars := [][]byte{make([]byte, 31), {0xff}, {0x01}}
+	for i := 0; i < 10; i++ {
+		scalar := make([]byte, 31)
+		rand.Read(scalar)
+		scalars = append(scalars, scalar)
+	}
+
+	table := newPointTable(p0, 31)
+	for _, scalar := range scalars {
+		got := new(starkcurve.G1Jac).Set(shiftPoint)
+		table.add(got, scalar)
+
+		want := new(starkcurve.G1Jac).ScalarMultiplication(p0, new(big.Int).SetBytes(scalar))
+		want.AddAssign(shiftPoint)
+		assert.True(t, want.Equal(got), "scalar %x", scalar)
+	}
+}
+
+var pointBench *starkcurve.G1Jac
+
+// BenchmarkPointTable compares adding a multiple of a point looked up in its table with
+// computing it by scalar multiplication, as done with the nopedersentable tag.
+func BenchmarkPointTable(b *testing.B) {
+	scalar := make([]byte, 31)
+	rand.Read(scalar)
+	table := newPointTable(p0, 31)
+
+	b.Run("table", func(b *testing.B) {
+		result := new(starkcurve.G1Jac)
+		for n := 0; n < b.N; n++ {
+			result.Set(shiftPoint)
+			table.add(result, scalar)
+		}
+		pointBench = result
+	})
+	b.Run("scalar multiplication", func(b *testing.B) {
+		var bigInt big.Int
+		result := new(starkcurve.G1Jac)
+		for n := 0; n < b.N; n++ {
+			result.ScalarMultiplication(p0, bigInt.SetBytes(scalar))
+			result.AddAssign(shiftPoint)
+		}
+		pointBench = result
+	})
+}
@@ -0,0 +1,118 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"strconv"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+// Parameters of the Hades permutation used by StarkNet's Poseidon, as defined by
+// poseidon_utils.py in cairo-lang.
+const (
+	hadesWidth         = 3
+	hadesFullRounds    = 8
+	hadesPartialRounds = 83
+	hadesRounds        = hadesFullRounds + hadesPartialRounds
+)
+
+// hadesRoundConstants are added to the state at the start of each round.
+var hadesRoundConstants [hadesRounds][hadesWidth]fp.Element
+
+func init() {
+	// The i-th round constant is sha256("Hades" + i) mod p.
+	var constant big.Int
+	for round := range hadesRoundConstants {
+		for i := range hadesRoundConstants[round] {
+			digest := sha256.Sum256([]byte("Hades" + strconv.Itoa(round*hadesWidth+i)))
+			hadesRoundConstants[round][i].SetBigInt(constant.SetBytes(digest[:]))
+		}
+	}
+}
+
+// HadesPermutation applies the Hades permutation underlying Poseidon to state in place.
+func HadesPermutation(state *[hadesWidth]*felt.Felt) {
+	var values [hadesWidth]fp.Element
+	for i, value := range state {
+		values[i] = *value.Impl()
+	}
+	hadesPermutation(&values)
+	for i := range state {
+		state[i] = felt.NewFelt(&values[i])
+	}
+}
+
+func hadesPermutation(state *[hadesWidth]fp.Element) {
+	for round := 0; round < hadesRounds; round++ {
+		full := round < hadesFullRounds/2 || round >= hadesFullRounds/2+hadesPartialRounds
+
+		for i := range state {
+			state[i].Add(&state[i], &hadesRoundConstants[round][i])
+		}
+
+		// S-box: x^3, applied to the last element only in partial rounds
+		for i := range state {
+			if full || i == hadesWidth-1 {
+				var square fp.Element
+				square.Square(&state[i])
+				state[i].Mul(&state[i], &square)
+			}
+		}
+
+		mixLayer(state)
+	}
+}
+
+// mixLayer multiplies the state by the MDS matrix
+//
+//	[[3, 1, 1],
+//	 [1, -1, 1],
+//	 [1, 1, -2]]
+func mixLayer(state *[hadesWidth]fp.Element) {
+	var sum, double fp.Element
+	sum.Add(&state[0], &state[1]).Add(&sum, &state[2])
+
+	double.Double(&state[0])
+	state[0].Add(&sum, &double)
+
+	double.Double(&state[1])
+	state[1].Sub(&sum, &double)
+
+	double.Double(&state[2])
+	double.Add(&double, &state[2])
+	state[2].Sub(&sum, &double)
+}
+
+// Poseidon implements the Poseidon hash of two elements, which is the first element of the
+// permutation of [a, b, 2].
+func Poseidon(a, b *felt.Felt) *felt.Felt {
+	state := [hadesWidth]fp.Element{*a.Impl(), *b.Impl()}
+	state[2].SetUint64(2)
+	hadesPermutation(&state)
+	return felt.NewFelt(&state[0])
+}
+
+// PoseidonArray implements the Poseidon hash of an array: the elements, padded with a one and
+// enough zeros to reach an even length, are absorbed two at a time.
+func PoseidonArray(elems ...*felt.Felt) *felt.Felt {
+	var state [hadesWidth]fp.Element
+	var one fp.Element
+	one.SetOne()
+
+	for i := 0; i+1 < len(elems); i += 2 {
+		state[0].Add(&state[0], elems[i].Impl())
+		state[1].Add(&state[1], elems[i+1].Impl())
+		hadesPermutation(&state)
+	}
+
+	if len(elems)%2 == 1 {
+		state[0].Add(&state[0], elems[len(elems)-1].Impl())
+		state[1].Add(&state[1], &one)
+	} else {
+		state[0].Add(&state[0], &one)
+	}
+	hadesPermutation(&state)
+	return felt.NewFelt(&state[0])
+}
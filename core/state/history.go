@@ -0,0 +1,112 @@
+package state
+
+import (
+	"bytes"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/encoder"
+)
+
+// The history buckets map a key and the number of the block that changed it to the value the key had
+// before that block. The value of a key as of block N is then either the value recorded for the first
+// change after block N or, if the key has not changed since, its value in the latest state.
+
+// updateHistory records the values overwritten by the state update of the given block in the history
+// buckets. If add is false, the records of the block are removed instead.
+func (s *State) updateHistory(blockNumber uint64, reverseDiff *ReverseStateDiff, add bool) error {
+	numberBinary := encoder.Uint64Bytes(blockNumber)
+	put := func(key, value []byte) error {
+		if add {
+			return s.txn.Set(key, value)
+		}
+		return s.txn.Delete(key)
+	}
+
+	for addr, diff := range reverseDiff.StorageDiffs {
+		addrBytes := addr.Marshal()
+		for _, pair := range diff {
+			if err := put(db.ContractStorageHistory.Key(addrBytes, pair.Key.Marshal(), numberBinary),
+				pair.Value.Marshal()); err != nil {
+				return err
+			}
+		}
+	}
+
+	for addr, nonce := range reverseDiff.Nonces {
+		if err := put(db.ContractNonceHistory.Key(addr.Marshal(), numberBinary), nonce.Marshal()); err != nil {
+			return err
+		}
+	}
+
+	// contracts did not exist before they were deployed, which is recorded as an empty class hash
+	for _, addr := range reverseDiff.DeployedContracts {
+		if err := put(db.ContractClassHashHistory.Key(addr.Marshal(), numberBinary), []byte{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HistoricalState gives read access to the State as of a given block.
+type HistoricalState struct {
+	txn         db.Transaction
+	blockNumber uint64
+}
+
+// NewStateAt returns a reader of the State right after the state update of the given block was applied.
+func NewStateAt(txn db.Transaction, blockNumber uint64) *HistoricalState {
+	return &HistoricalState{txn: txn, blockNumber: blockNumber}
+}
+
+// GetContractClass returns class hash of a contract at a given address.
+// If the contract was not deployed yet, [db.ErrKeyNotFound] is returned.
+func (h *HistoricalState) GetContractClass(addr *felt.Felt) (*felt.Felt, error) {
+	val, found, err := h.valueAt(db.ContractClassHashHistory.Key(addr.Marshal()))
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return NewState(h.txn).GetContractClass(addr)
+	} else if len(val) == 0 {
+		return nil, db.ErrKeyNotFound
+	}
+	return new(felt.Felt).SetBytes(val), nil
+}
+
+// GetContractNonce returns nonce of a contract at a given address.
+// If the contract was not deployed yet, [db.ErrKeyNotFound] is returned.
+func (h *HistoricalState) GetContractNonce(addr *felt.Felt) (*felt.Felt, error) {
+	if _, err := h.GetContractClass(addr); err != nil {
+		return nil, err
+	}
+
+	val, found, err := h.valueAt(db.ContractNonceHistory.Key(addr.Marshal()))
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return NewState(h.txn).GetContractNonce(addr)
+	}
+	return new(felt.Felt).SetBytes(val), nil
+}
+
+// GetContractStorage returns the value of a storage slot of the contract at a given address.
+// Zero is returned for slots that were not set.
+func (h *HistoricalState) GetContractStorage(addr, key *felt.Felt) (*felt.Felt, error) {
+	val, found, err := h.valueAt(db.ContractStorageHistory.Key(addr.Marshal(), key.Marshal()))
+	if err != nil {
+		return nil, err
+	} else if found {
+		return new(felt.Felt).SetBytes(val), nil
+	}
+	return NewState(h.txn).GetContractStorage(addr, key)
+}
+
+// valueAt looks up the value the key given by prefix had as of the block of the [HistoricalState].
+// If the key has not changed since that block, false is returned.
+func (h *HistoricalState) valueAt(prefix []byte) ([]byte, bool, error) {
+	entry, err := h.txn.Seek(append(prefix, encoder.Uint64Bytes(h.blockNumber+1)...))
+	if err != nil || entry == nil || !bytes.HasPrefix(entry.Key, prefix) {
+		return nil, false, err
+	}
+	return entry.Value, true, nil
+}
@@ -0,0 +1,115 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoricalState(t *testing.T) {
+	addrA, addrB := new(felt.Felt).SetUint64(0xa), new(felt.Felt).SetUint64(0xb)
+	classA, classB := new(felt.Felt).SetUint64(0xca), new(felt.Felt).SetUint64(0xcb)
+	key1, key2 := new(felt.Felt).SetUint64(1), new(felt.Felt).SetUint64(2)
+
+	diffs := []*core.StateDiff{
+		{
+			DeployedContracts: []core.DeployedContract{{Address: addrA, ClassHash: classA}},
+			StorageDiffs:      map[felt.Felt][]core.StorageDiff{*addrA: {{Key: key1, Value: new(felt.Felt).SetUint64(10)}}},
+		},
+		{
+			Nonces:       map[felt.Felt]*felt.Felt{*addrA: new(felt.Felt).SetUint64(1)},
+			StorageDiffs: map[felt.Felt][]core.StorageDiff{*addrA: {{Key: key1, Value: new(felt.Felt).SetUint64(20)}}},
+		},
+		{
+			DeployedContracts: []core.DeployedContract{{Address: addrB, ClassHash: classB}},
+			StorageDiffs:      map[felt.Felt][]core.StorageDiff{*addrA: {{Key: key2, Value: new(felt.Felt).SetUint64(5)}}},
+		},
+	}
+
+	// calculate the roots by applying the diffs on a scratch state
+	roots := []*felt.Felt{new(felt.Felt)}
+	scratch := NewState(db.NewTestDb().NewTransaction(true))
+	for _, diff := range diffs {
+		for _, contract := range diff.DeployedContracts {
+			require.NoError(t, scratch.putNewContract(contract.Address, contract.ClassHash))
+		}
+		for addr, nonce := range diff.Nonces {
+			addr := addr
+			require.NoError(t, scratch.updateContractNonce(&addr, nonce))
+		}
+		for addr, storageDiff := range diff.StorageDiffs {
+			addr := addr
+			require.NoError(t, scratch.updateContractStorage(&addr, storageDiff))
+		}
+		root, err := scratch.Root()
+		require.NoError(t, err)
+		roots = append(roots, root)
+	}
+
+	txn := db.NewTestDb().NewTransaction(true)
+	state := NewState(txn)
+	for i, diff := range diffs {
+		require.NoError(t, state.Update(uint64(i), &core.StateUpdate{
+			OldRoot:   roots[i],
+			NewRoot:   roots[i+1],
+			StateDiff: diff,
+		}))
+	}
+
+	assertStorage := func(t *testing.T, blockNumber uint64, addr, key *felt.Felt, want uint64) {
+		got, err := NewStateAt(txn, blockNumber).GetContractStorage(addr, key)
+		require.NoError(t, err)
+		assert.Equal(t, new(felt.Felt).SetUint64(want), got)
+	}
+	assertNonce := func(t *testing.T, blockNumber uint64, addr *felt.Felt, want uint64) {
+		got, err := NewStateAt(txn, blockNumber).GetContractNonce(addr)
+		require.NoError(t, err)
+		assert.Equal(t, new(felt.Felt).SetUint64(want), got)
+	}
+
+	t.Run("storage", func(t *testing.T) {
+		assertStorage(t, 0, addrA, key1, 10)
+		assertStorage(t, 1, addrA, key1, 20)
+		assertStorage(t, 2, addrA, key1, 20)
+		assertStorage(t, 1, addrA, key2, 0)
+		assertStorage(t, 2, addrA, key2, 5)
+		assertStorage(t, 1, addrB, key1, 0)
+	})
+
+	t.Run("nonce", func(t *testing.T) {
+		assertNonce(t, 0, addrA, 0)
+		assertNonce(t, 1, addrA, 1)
+		assertNonce(t, 2, addrA, 1)
+
+		_, err := NewStateAt(txn, 1).GetContractNonce(addrB)
+		assert.ErrorIs(t, err, db.ErrKeyNotFound)
+		assertNonce(t, 2, addrB, 0)
+	})
+
+	t.Run("class hash", func(t *testing.T) {
+		for blockNumber := uint64(0); blockNumber < 3; blockNumber++ {
+			got, err := NewStateAt(txn, blockNumber).GetContractClass(addrA)
+			require.NoError(t, err)
+			assert.Equal(t, classA, got)
+		}
+
+		_, err := NewStateAt(txn, 1).GetContractClass(addrB)
+		assert.ErrorIs(t, err, db.ErrKeyNotFound)
+		got, err := NewStateAt(txn, 2).GetContractClass(addrB)
+		require.NoError(t, err)
+		assert.Equal(t, classB, got)
+	})
+
+	t.Run("revert removes history", func(t *testing.T) {
+		require.NoError(t, state.Revert(2))
+
+		assertStorage(t, 2, addrA, key2, 0)
+		assertStorage(t, 1, addrA, key1, 20)
+		_, err := NewStateAt(txn, 2).GetContractClass(addrB)
+		assert.ErrorIs(t, err, db.ErrKeyNotFound)
+	})
+}
@@ -0,0 +1,74 @@
+package state
+
+import (
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+)
+
+// StateReader gives read access to the contracts of a state.
+type StateReader interface {
+	GetContractClass(addr *felt.Felt) (*felt.Felt, error)
+	GetContractNonce(addr *felt.Felt) (*felt.Felt, error)
+	GetContractStorage(addr, key *felt.Felt) (*felt.Felt, error)
+}
+
+var (
+	_ StateReader = (*State)(nil)
+	_ StateReader = (*HistoricalState)(nil)
+	_ StateReader = (*PendingState)(nil)
+)
+
+// PendingState gives read access to the state of the pending block, that is the
+// state diff of the pending block layered on top of the state of its parent.
+// Nothing is written to the database.
+type PendingState struct {
+	diff *core.StateDiff
+	head StateReader
+}
+
+// NewPendingState returns a reader of the state resulting from applying diff to head.
+func NewPendingState(diff *core.StateDiff, head StateReader) *PendingState {
+	return &PendingState{diff: diff, head: head}
+}
+
+func (p *PendingState) GetContractClass(addr *felt.Felt) (*felt.Felt, error) {
+	if classHash := p.deployedClassHash(addr); classHash != nil {
+		return classHash, nil
+	}
+	return p.head.GetContractClass(addr)
+}
+
+func (p *PendingState) GetContractNonce(addr *felt.Felt) (*felt.Felt, error) {
+	if nonce, ok := p.diff.Nonces[*addr]; ok {
+		return nonce, nil
+	} else if p.deployedClassHash(addr) != nil {
+		return new(felt.Felt), nil
+	}
+	return p.head.GetContractNonce(addr)
+}
+
+func (p *PendingState) GetContractStorage(addr, key *felt.Felt) (*felt.Felt, error) {
+	diffs := p.diff.StorageDiffs[*addr]
+	// later entries overwrite earlier ones
+	for i := len(diffs) - 1; i >= 0; i-- {
+		if diffs[i].Key.Equal(key) {
+			return diffs[i].Value, nil
+		}
+	}
+
+	if p.deployedClassHash(addr) != nil {
+		return new(felt.Felt), nil
+	}
+	return p.head.GetContractStorage(addr, key)
+}
+
+// deployedClassHash returns the class hash of the contract at addr if it is deployed by
+// the pending block, nil otherwise.
+func (p *PendingState) deployedClassHash(addr *felt.Felt) *felt.Felt {
+	for _, deployed := range p.diff.DeployedContracts {
+		if deployed.Address.Equal(addr) {
+			return deployed.ClassHash
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingState(t *testing.T) {
+	addrA, addrB, addrC := new(felt.Felt).SetUint64(0xa), new(felt.Felt).SetUint64(0xb), new(felt.Felt).SetUint64(0xc)
+	classA, classB := new(felt.Felt).SetUint64(0xca), new(felt.Felt).SetUint64(0xcb)
+	key1, key2 := new(felt.Felt).SetUint64(1), new(felt.Felt).SetUint64(2)
+
+	head := NewState(db.NewTestDb().NewTransaction(true))
+	require.NoError(t, head.putNewContract(addrA, classA))
+	require.NoError(t, head.updateContractNonce(addrA, new(felt.Felt).SetUint64(3)))
+	require.NoError(t, head.updateContractStorage(addrA, []core.StorageDiff{
+		{Key: key1, Value: new(felt.Felt).SetUint64(10)},
+		{Key: key2, Value: new(felt.Felt).SetUint64(20)},
+	}))
+
+	pending := NewPendingState(&core.StateDiff{
+		DeployedContracts: []core.DeployedContract{{Address: addrB, ClassHash: classB}},
+		Nonces:            map[felt.Felt]*felt.Felt{*addrA: new(felt.Felt).SetUint64(4)},
+		StorageDiffs: map[felt.Felt][]core.StorageDiff{
+			*addrA: {{Key: key1, Value: new(felt.Felt).SetUint64(11)}, {Key: key1, Value: new(felt.Felt).SetUint64(12)}},
+			*addrB: {{Key: key2, Value: new(felt.Felt).SetUint64(5)}},
+		},
+	}, head)
+
+	t.Run("class", func(t *testing.T) {
+		got, err := pending.GetContractClass(addrA)
+		require.NoError(t, err)
+		assert.Equal(t, classA, got)
+
+		got, err = pending.GetContractClass(addrB)
+		require.NoError(t, err)
+		assert.Equal(t, classB, got)
+
+		_, err = pending.GetContractClass(addrC)
+		assert.ErrorIs(t, err, db.ErrKeyNotFound)
+	})
+
+	t.Run("nonce", func(t *testing.T) {
+		got, err := pending.GetContractNonce(addrA)
+		require.NoError(t, err)
+		assert.Equal(t, new(felt.Felt).SetUint64(4), got)
+
+		got, err = pending.GetContractNonce(addrB)
+		require.NoError(t, err)
+		assert.Equal(t, new(felt.Felt), got)
+	})
+
+	t.Run("storage", func(t *testing.T) {
+		for _, test := range []struct {
+			addr, key *felt.Felt
+			want      uint64
+		}{
+			{addrA, key1, 12},
+			{addrA, key2, 20},
+			{addrB, key1, 0},
+			{addrB, key2, 5},
+		} {
+			got, err := pending.GetContractStorage(test.addr, test.key)
+			require.NoError(t, err)
+			assert.Equal(t, new(felt.Felt).SetUint64(test.want), got)
+		}
+	})
+
+	// the head state is left untouched
+	got, err := head.GetContractStorage(addrA, key1)
+	require.NoError(t, err)
+	assert.Equal(t, new(felt.Felt).SetUint64(10), got)
+}
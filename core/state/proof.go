@@ -0,0 +1,81 @@
+package state
+
+import (
+	"errors"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/core/trie/proof"
+	"github.com/NethermindEth/juno/db"
+)
+
+// StorageProof proves the value of a storage slot against the storage root of a contract.
+type StorageProof struct {
+	Key   *felt.Felt
+	Value *felt.Felt
+	Proof []proof.Node
+}
+
+// Proof proves the storage of a contract against the state root.
+//
+// ContractProof proves the commitment of the contract, see [CalculateContractCommitment],
+// in the global state trie. If the contract does not exist, ContractProof is a proof of
+// absence and all the other fields are nil.
+type Proof struct {
+	ContractProof []proof.Node
+	ClassHash     *felt.Felt
+	Nonce         *felt.Felt
+	StorageRoot   *felt.Felt
+	StorageProofs []*StorageProof
+}
+
+// GetProof returns a [Proof] of the contract at contractAddress and the given slots of its storage.
+func (s *State) GetProof(contractAddress *felt.Felt, storageKeys []*felt.Felt) (*Proof, error) {
+	stateStorage, err := s.getStateStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	proof := new(Proof)
+	if proof.ContractProof, err = stateStorage.Prove(contractAddress); err != nil {
+		return nil, err
+	}
+
+	contract := core.NewContract(contractAddress, s.txn)
+	if proof.ClassHash, err = contract.ClassHash(); err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			proof.ClassHash = nil
+			return proof, nil
+		}
+		return nil, err
+	}
+	if proof.Nonce, err = contract.Nonce(); err != nil {
+		return nil, err
+	}
+
+	storage, err := contract.Storage()
+	if err != nil {
+		return nil, err
+	}
+	if proof.StorageRoot, err = storage.Root(); err != nil {
+		return nil, err
+	}
+
+	proof.StorageProofs = make([]*StorageProof, 0, len(storageKeys))
+	for _, key := range storageKeys {
+		value, err := s.GetContractStorage(contractAddress, key)
+		if err != nil {
+			return nil, err
+		}
+		storageProof, err := storage.Prove(key)
+		if err != nil {
+			return nil, err
+		}
+		proof.StorageProofs = append(proof.StorageProofs, &StorageProof{
+			Key:   key,
+			Value: value,
+			Proof: storageProof,
+		})
+	}
+	return proof, nil
+}
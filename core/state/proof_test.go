@@ -0,0 +1,59 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/core/trie/proof"
+	"github.com/NethermindEth/juno/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProof(t *testing.T) {
+	state := NewState(db.NewTestDb().NewTransaction(true))
+	addr, other := new(felt.Felt).SetUint64(44), new(felt.Felt).SetUint64(45)
+	classHash := new(felt.Felt).SetUint64(37)
+	keys := []*felt.Felt{new(felt.Felt).SetUint64(1), new(felt.Felt).SetUint64(2), new(felt.Felt).SetUint64(3)}
+
+	require.NoError(t, state.putNewContract(addr, classHash))
+	require.NoError(t, state.putNewContract(other, classHash))
+	require.NoError(t, state.updateContractStorage(addr, []core.StorageDiff{
+		{Key: keys[0], Value: new(felt.Felt).SetUint64(10)},
+		{Key: keys[1], Value: new(felt.Felt).SetUint64(20)},
+	}))
+	root, err := state.Root()
+	require.NoError(t, err)
+
+	t.Run("deployed contract", func(t *testing.T) {
+		stateProof, err := state.GetProof(addr, keys)
+		require.NoError(t, err)
+
+		assert.Equal(t, classHash, stateProof.ClassHash)
+		assert.Equal(t, new(felt.Felt), stateProof.Nonce)
+		commitment := CalculateContractCommitment(stateProof.StorageRoot, stateProof.ClassHash, stateProof.Nonce)
+		assert.True(t, proof.Verify(root, addr, commitment, stateProof.ContractProof, stateTrieHeight))
+
+		require.Len(t, stateProof.StorageProofs, 3)
+		for i, want := range []uint64{10, 20, 0} {
+			storageProof := stateProof.StorageProofs[i]
+			assert.Equal(t, keys[i], storageProof.Key)
+			assert.Equal(t, new(felt.Felt).SetUint64(want), storageProof.Value)
+			assert.True(t, proof.Verify(stateProof.StorageRoot, storageProof.Key, storageProof.Value,
+				storageProof.Proof, contractStorageTrieHeight))
+		}
+	})
+
+	t.Run("unknown contract", func(t *testing.T) {
+		stateProof, err := state.GetProof(new(felt.Felt).SetUint64(46), keys)
+		require.NoError(t, err)
+
+		assert.True(t, proof.Verify(root, new(felt.Felt).SetUint64(46), new(felt.Felt),
+			stateProof.ContractProof, stateTrieHeight))
+		assert.Nil(t, stateProof.ClassHash)
+		assert.Nil(t, stateProof.Nonce)
+		assert.Nil(t, stateProof.StorageRoot)
+		assert.Nil(t, stateProof.StorageProofs)
+	})
+}
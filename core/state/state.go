@@ -1,6 +1,7 @@
 package state
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/NethermindEth/juno/core"
@@ -8,6 +9,7 @@ import (
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/core/trie"
 	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/encoder"
 	"github.com/bits-and-blooms/bitset"
 )
 
@@ -68,6 +70,21 @@ func (s *State) GetContractNonce(addr *felt.Felt) (*felt.Felt, error) {
 	return core.NewContract(addr, s.txn).Nonce()
 }
 
+// GetContractStorage returns the value of a storage slot of the contract at a given address.
+// Zero is returned for slots that are not set.
+func (s *State) GetContractStorage(addr, key *felt.Felt) (*felt.Felt, error) {
+	storage, err := core.NewContract(addr, s.txn).Storage()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := storage.Get(key)
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return new(felt.Felt), nil
+	}
+	return value, err
+}
+
 // Root returns the state commitment.
 func (s *State) Root() (*felt.Felt, error) {
 	storage, err := s.getStateStorage()
@@ -119,11 +136,30 @@ func (s *State) putStateStorage(state *trie.Trie) error {
 	return nil
 }
 
+// ReverseStateDiff records what is needed to undo the state update of a block:
+// the values that the storage slots and nonces touched by the update had before
+// it was applied, and the contracts it deployed.
+type ReverseStateDiff struct {
+	// The state root before the update, which is restored by [State.Revert].
+	OldRoot *felt.Felt
+	// The state root after the update.
+	NewRoot *felt.Felt
+	// Previous values of the updated storage slots, zero for slots that were not set.
+	StorageDiffs map[felt.Felt][]core.StorageDiff
+	// Previous values of the updated nonces, zero for contracts that did not exist.
+	Nonces map[felt.Felt]*felt.Felt
+	// Addresses of the contracts deployed by the update.
+	DeployedContracts []*felt.Felt
+}
+
 // Update applies a StateUpdate to the State object. State is not
 // updated if an error is encountered during the operation. If update's
 // old or new root does not match the state's old or new roots,
 // [ErrMismatchedRoot] is returned.
-func (s *State) Update(update *core.StateUpdate) error {
+//
+// The previous values of the keys touched by update are recorded under
+// blockNumber so that the update can be undone with [State.Revert].
+func (s *State) Update(blockNumber uint64, update *core.StateUpdate) error {
 	currentRoot, err := s.Root()
 	if err != nil {
 		return err
@@ -136,6 +172,11 @@ func (s *State) Update(update *core.StateUpdate) error {
 		}
 	}
 
+	reverseDiff, err := s.reverseStateDiff(update)
+	if err != nil {
+		return err
+	}
+
 	// register deployed contracts
 	for _, contract := range update.StateDiff.DeployedContracts {
 		if err := s.putNewContract(contract.Address, contract.ClassHash); err != nil {
@@ -171,7 +212,177 @@ func (s *State) Update(update *core.StateUpdate) error {
 			IsOld: false,
 		}
 	}
-	return nil
+
+	reverseDiffBinary, err := encoder.Marshal(reverseDiff)
+	if err != nil {
+		return err
+	}
+	if err = s.txn.Set(reverseStateDiffKey(blockNumber), reverseDiffBinary); err != nil {
+		return err
+	}
+	return s.updateHistory(blockNumber, reverseDiff, true)
+}
+
+// reverseStateDiff collects the values that the storage slots and nonces touched
+// by update have in the current state. It should be called before update is applied.
+func (s *State) reverseStateDiff(update *core.StateUpdate) (*ReverseStateDiff, error) {
+	diff := update.StateDiff
+	reverseDiff := &ReverseStateDiff{
+		OldRoot:           update.OldRoot,
+		NewRoot:           update.NewRoot,
+		StorageDiffs:      make(map[felt.Felt][]core.StorageDiff, len(diff.StorageDiffs)),
+		Nonces:            make(map[felt.Felt]*felt.Felt, len(diff.Nonces)),
+		DeployedContracts: make([]*felt.Felt, 0, len(diff.DeployedContracts)),
+	}
+
+	for addr, storageDiffs := range diff.StorageDiffs {
+		addr := addr
+		oldValues := make([]core.StorageDiff, 0, len(storageDiffs))
+		for _, storageDiff := range storageDiffs {
+			oldValue, err := s.GetContractStorage(&addr, storageDiff.Key)
+			if err != nil {
+				return nil, err
+			}
+			oldValues = append(oldValues, core.StorageDiff{Key: storageDiff.Key, Value: oldValue})
+		}
+		reverseDiff.StorageDiffs[addr] = oldValues
+	}
+
+	for addr := range diff.Nonces {
+		addr := addr
+		oldNonce, err := s.GetContractNonce(&addr)
+		if errors.Is(err, db.ErrKeyNotFound) {
+			oldNonce = new(felt.Felt)
+		} else if err != nil {
+			return nil, err
+		}
+		reverseDiff.Nonces[addr] = oldNonce
+	}
+
+	for _, contract := range diff.DeployedContracts {
+		reverseDiff.DeployedContracts = append(reverseDiff.DeployedContracts, contract.Address)
+	}
+
+	return reverseDiff, nil
+}
+
+// ReverseStateDiff returns the [ReverseStateDiff] recorded when the state update of the
+// given block was applied. It is useful to inspect which keys a block touched when
+// debugging state root mismatches.
+func (s *State) ReverseStateDiff(blockNumber uint64) (*ReverseStateDiff, error) {
+	reverseDiffBinary, err := s.txn.Get(reverseStateDiffKey(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	reverseDiff := new(ReverseStateDiff)
+	return reverseDiff, encoder.Unmarshal(reverseDiffBinary, reverseDiff)
+}
+
+// Revert undoes the state update of the given block, which must be the last
+// update applied to the State. The contracts deployed by the update are removed
+// from the state. If the state root does not match the update's new root before
+// reverting or the update's old root after reverting, [ErrMismatchedRoot] is returned.
+func (s *State) Revert(blockNumber uint64) error {
+	reverseDiff, err := s.ReverseStateDiff(blockNumber)
+	if err != nil {
+		return err
+	}
+
+	currentRoot, err := s.Root()
+	if err != nil {
+		return err
+	}
+	if !reverseDiff.NewRoot.Equal(currentRoot) {
+		return &ErrMismatchedRoot{
+			Want:  reverseDiff.NewRoot,
+			Got:   currentRoot,
+			IsOld: false,
+		}
+	}
+
+	deployed := make(map[felt.Felt]struct{}, len(reverseDiff.DeployedContracts))
+	for _, addr := range reverseDiff.DeployedContracts {
+		deployed[*addr] = struct{}{}
+	}
+
+	// restore contract storages
+	for addr, diff := range reverseDiff.StorageDiffs {
+		addr := addr
+		if err = core.NewContract(&addr, s.txn).UpdateStorage(diff); err != nil {
+			return err
+		}
+	}
+
+	// restore contract nonces
+	for addr, nonce := range reverseDiff.Nonces {
+		addr := addr
+		if err = core.NewContract(&addr, s.txn).UpdateNonce(nonce); err != nil {
+			return err
+		}
+	}
+
+	// recalculate the commitments of the contracts that existed before the update
+	touched := make(map[felt.Felt]struct{}, len(reverseDiff.StorageDiffs)+len(reverseDiff.Nonces))
+	for addr := range reverseDiff.StorageDiffs {
+		touched[addr] = struct{}{}
+	}
+	for addr := range reverseDiff.Nonces {
+		touched[addr] = struct{}{}
+	}
+	for addr := range touched {
+		addr := addr
+		if _, ok := deployed[addr]; ok {
+			continue
+		}
+		if err = s.updateContractCommitment(core.NewContract(&addr, s.txn)); err != nil {
+			return err
+		}
+	}
+
+	// remove deployed contracts
+	for _, addr := range reverseDiff.DeployedContracts {
+		if err = s.removeContract(addr); err != nil {
+			return err
+		}
+	}
+
+	oldRoot, err := s.Root()
+	if err != nil {
+		return err
+	}
+	if !reverseDiff.OldRoot.Equal(oldRoot) {
+		return &ErrMismatchedRoot{
+			Want:  reverseDiff.OldRoot,
+			Got:   oldRoot,
+			IsOld: true,
+		}
+	}
+	if err = s.updateHistory(blockNumber, reverseDiff, false); err != nil {
+		return err
+	}
+	return s.txn.Delete(reverseStateDiffKey(blockNumber))
+}
+
+func reverseStateDiffKey(blockNumber uint64) []byte {
+	return db.ReverseStateDiffs.Key(encoder.Uint64Bytes(blockNumber))
+}
+
+// removeContract deletes the contract at the given address and its
+// commitment from the global state Trie.
+func (s *State) removeContract(addr *felt.Felt) error {
+	if err := core.NewContract(addr, s.txn).Purge(); err != nil {
+		return err
+	}
+
+	state, err := s.getStateStorage()
+	if err != nil {
+		return err
+	}
+	if _, err = state.Put(addr, new(felt.Felt)); err != nil {
+		return err
+	}
+	return s.putStateStorage(state)
 }
 
 // updateContractStorage applies the diff set to the Trie of the
@@ -11,6 +11,7 @@ import (
 	"github.com/NethermindEth/juno/db"
 	"github.com/bits-and-blooms/bitset"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestState_PutNewContract(t *testing.T) {
@@ -218,7 +219,7 @@ func TestUpdate(t *testing.T) {
 	testDb := db.NewTestDb()
 	state := NewState(testDb.NewTransaction(true))
 
-	assert.Equal(t, nil, state.Update(coreUpdate))
+	assert.Equal(t, nil, state.Update(0, coreUpdate))
 }
 
 func TestUpdateNonce(t *testing.T) {
@@ -237,7 +238,7 @@ func TestUpdateNonce(t *testing.T) {
 	testDb := db.NewTestDb()
 	state := NewState(testDb.NewTransaction(true))
 
-	assert.NoError(t, state.Update(coreUpdate))
+	assert.NoError(t, state.Update(0, coreUpdate))
 
 	nonce, err := state.GetContractNonce(addr)
 	assert.NoError(t, err)
@@ -251,9 +252,92 @@ func TestUpdateNonce(t *testing.T) {
 
 	nonce.SetUint64(1)
 	coreUpdate.StateDiff.Nonces[*addr] = nonce
-	assert.NoError(t, state.Update(coreUpdate))
+	assert.NoError(t, state.Update(1, coreUpdate))
 
 	newNonce, err := state.GetContractNonce(addr)
 	assert.NoError(t, err)
 	assert.Equal(t, true, nonce.Equal(newNonce))
 }
+
+func TestRevert(t *testing.T) {
+	addr, _ := new(felt.Felt).SetString("0x20cfa74ee3564b4cd5435cdace0f9c4d43b939620e4a0bb5076105df0a626c6")
+	classHash, _ := new(felt.Felt).SetString("0x10455c752b86932ce552f2b0fe81a880746649b9aee7e0d842bf3f52378f9f8")
+	root0, _ := new(felt.Felt).SetString("0x4bdef7bf8b81a868aeab4b48ef952415fe105ab479e2f7bc671c92173542368")
+	root1, _ := new(felt.Felt).SetString("0x6210642ffd49f64617fc9e5c0bbe53a6a92769e2996eb312a42d2bdb7f2afc1")
+
+	deployUpdate := &core.StateUpdate{
+		OldRoot: new(felt.Felt),
+		NewRoot: root0,
+		StateDiff: &core.StateDiff{
+			DeployedContracts: []core.DeployedContract{{Address: addr, ClassHash: classHash}},
+		},
+	}
+	nonceUpdate := &core.StateUpdate{
+		OldRoot: root0,
+		NewRoot: root1,
+		StateDiff: &core.StateDiff{
+			Nonces: map[felt.Felt]*felt.Felt{*addr: new(felt.Felt).SetUint64(1)},
+		},
+	}
+
+	testDb := db.NewTestDb()
+	state := NewState(testDb.NewTransaction(true))
+
+	for i, update := range []*core.StateUpdate{deployUpdate, nonceUpdate} {
+		require.NoError(t, state.Update(uint64(i), update))
+	}
+
+	reverseDiff, err := state.ReverseStateDiff(1)
+	require.NoError(t, err)
+	assert.Equal(t, &ReverseStateDiff{
+		OldRoot:           root0,
+		NewRoot:           root1,
+		StorageDiffs:      map[felt.Felt][]core.StorageDiff{},
+		Nonces:            map[felt.Felt]*felt.Felt{*addr: new(felt.Felt)},
+		DeployedContracts: []*felt.Felt{},
+	}, reverseDiff)
+
+	t.Run("not the last update", func(t *testing.T) {
+		assert.ErrorAs(t, state.Revert(0), new(*ErrMismatchedRoot))
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		assert.ErrorIs(t, state.Revert(2), db.ErrKeyNotFound)
+	})
+
+	require.NoError(t, state.Revert(1))
+	nonce, err := state.GetContractNonce(addr)
+	require.NoError(t, err)
+	assert.Equal(t, new(felt.Felt), nonce)
+	_, err = state.ReverseStateDiff(1)
+	assert.ErrorIs(t, err, db.ErrKeyNotFound)
+
+	require.NoError(t, state.Revert(0))
+	_, err = state.GetContractClass(addr)
+	assert.ErrorIs(t, err, db.ErrKeyNotFound)
+	root, err := state.Root()
+	require.NoError(t, err)
+	assert.Equal(t, new(felt.Felt), root)
+}
+
+func TestGetContractStorage(t *testing.T) {
+	state := NewState(db.NewTestDb().NewTransaction(true))
+	addr := new(felt.Felt).SetUint64(44)
+	key := new(felt.Felt).SetUint64(1)
+	value := new(felt.Felt).SetUint64(37)
+
+	got, err := state.GetContractStorage(addr, key)
+	require.NoError(t, err)
+	assert.Equal(t, new(felt.Felt), got)
+
+	require.NoError(t, state.putNewContract(addr, new(felt.Felt).SetUint64(2)))
+	require.NoError(t, state.updateContractStorage(addr, []core.StorageDiff{{Key: key, Value: value}}))
+
+	got, err = state.GetContractStorage(addr, key)
+	require.NoError(t, err)
+	assert.Equal(t, value, got)
+
+	got, err = state.GetContractStorage(addr, new(felt.Felt).SetUint64(2))
+	require.NoError(t, err)
+	assert.Equal(t, new(felt.Felt), got)
+}
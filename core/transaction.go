@@ -72,6 +72,14 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	err = encoder.RegisterType(reflect.TypeOf(DeployAccountTransaction{}))
+	if err != nil {
+		panic(err)
+	}
+	err = encoder.RegisterType(reflect.TypeOf(L1HandlerTransaction{}))
+	if err != nil {
+		panic(err)
+	}
 }
 
 type TransactionReceipt struct {
@@ -223,3 +231,93 @@ func (d *DeclareTransaction) Hash(network utils.Network) (*felt.Felt, error) {
 	}
 	return nil, errors.New("invalid transaction version")
 }
+
+type DeployAccountTransaction struct {
+	// A random number used to distinguish between different instances of the contract.
+	ContractAddressSalt *felt.Felt
+	// The address of the account contract being deployed.
+	ContractAddress *felt.Felt
+	// The class that defines the account contract’s functionality.
+	ClassHash *felt.Felt
+	// The arguments passed to the constructor during deployment.
+	ConstructorCallData []*felt.Felt
+	// The maximum fee that the sender is willing to pay for the transaction.
+	MaxFee *felt.Felt
+	// Additional information given by the sender, used to validate the transaction.
+	Signature []*felt.Felt
+	// The transaction nonce.
+	Nonce *felt.Felt
+	// The transaction’s version. The only possible value is 1.
+	//
+	// When the fields that comprise a transaction change,
+	// either with the addition of a new field or the removal of an existing field,
+	// then the transaction version increases.
+	Version *felt.Felt
+}
+
+func (d *DeployAccountTransaction) Hash(network utils.Network) (*felt.Felt, error) {
+	if d.Version.IsOne() {
+		callData := make([]*felt.Felt, 0, len(d.ConstructorCallData)+2)
+		callData = append(callData, d.ClassHash, d.ContractAddressSalt)
+		callData = append(callData, d.ConstructorCallData...)
+		return crypto.PedersenArray(
+			new(felt.Felt).SetBytes([]byte("deploy_account")),
+			d.Version,
+			d.ContractAddress,
+			new(felt.Felt),
+			crypto.PedersenArray(callData...),
+			d.MaxFee,
+			network.ChainId(),
+			d.Nonce,
+		), nil
+	}
+	return nil, errors.New("invalid transaction version")
+}
+
+type L1HandlerTransaction struct {
+	// The address of the contract handling the message.
+	ContractAddress *felt.Felt
+	// The encoding of the selector for the l1_handler invoked (the entry point in the contract)
+	EntryPointSelector *felt.Felt
+	// The nonce of the message sent from L1. Not set for the earliest mainnet transactions.
+	Nonce *felt.Felt
+	// The arguments passed to the l1_handler: the L1 sender address followed by the message payload.
+	CallData []*felt.Felt
+	// When the fields that comprise a transaction change,
+	// either with the addition of a new field or the removal of an existing field,
+	// then the transaction version increases.
+	Version *felt.Felt
+}
+
+// Hash computes the hash of an L1 handler transaction. Transactions without a nonce predate
+// the l1_handler hash prefix and are hashed as version 0 invoke transactions.
+//
+// Todo: a few L1 handler transactions from early mainnet blocks carry a nonce but were hashed
+// without the version and max fee fields, which cannot be told apart from the transaction alone.
+func (l *L1HandlerTransaction) Hash(network utils.Network) (*felt.Felt, error) {
+	version := l.Version
+	if version == nil {
+		version = new(felt.Felt)
+	} else if !version.IsZero() {
+		return nil, errors.New("invalid transaction version")
+	}
+	if l.Nonce == nil {
+		return crypto.PedersenArray(
+			new(felt.Felt).SetBytes([]byte("invoke")),
+			l.ContractAddress,
+			l.EntryPointSelector,
+			crypto.PedersenArray(l.CallData...),
+			network.ChainId(),
+		), nil
+	}
+	return crypto.PedersenArray(
+		new(felt.Felt).SetBytes([]byte("l1_handler")),
+		version,
+		l.ContractAddress,
+		l.EntryPointSelector,
+		crypto.PedersenArray(l.CallData...),
+		new(felt.Felt),
+		network.ChainId(),
+		l.Nonce,
+	), nil
+}
@@ -197,6 +197,129 @@ func TestDeclareTransaction(t *testing.T) {
 	}
 }
 
+func TestDeployAccountTransaction(t *testing.T) {
+	tests := map[string]struct {
+		input   DeployAccountTransaction
+		network utils.Network
+		want    *felt.Felt
+	}{
+		// https://alpha-mainnet.starknet.io/feeder_gateway/get_transaction?transactionHash=0x546afb8137c8ce1724e9e2423f21e8000e4d2a50d59486d90be1e545643763e
+		"Deploy account transaction version 1": {
+			input: DeployAccountTransaction{
+				ContractAddressSalt: hexToFelt("0x2e69842ec64e60ffc1db828a8f112a829b26324eefb682e25ef73b4a1a50777"),
+				ContractAddress:     hexToFelt("0x4abffbd8f173caa412f64b6a9ae7dd640f297870f6072ca19905bc0677cb027"),
+				ClassHash:           hexToFelt("0x25ec026985a3bf9d0cc1fe17326b245dfdc3ff89b8fde106542a3ea56c5a918"),
+				ConstructorCallData: [](*felt.Felt){
+					hexToFelt("0x33434ad846cdd5f23eb73ff09fe6fddd568284a0fb7d1be20ee482f044dabe2"),
+					hexToFelt("0x79dc0da7c54b95f10aa182ad0a46400db63156920adb65eca2654c0945a463"),
+					hexToFelt("0x2"),
+					hexToFelt("0x2e69842ec64e60ffc1db828a8f112a829b26324eefb682e25ef73b4a1a50777"),
+					hexToFelt("0x0"),
+				},
+				MaxFee: hexToFelt("0x6757cb88aa0c"),
+				Signature: [](*felt.Felt){
+					hexToFelt("0x3db7e93b01c7857c538c6974dacd64c25bd5585fb9a8c0ae2d2203165094694"),
+					hexToFelt("0x7d4ab5a89bbb9c8667282ef6a866abfd505700eb07c19ba7367f0f12c95735"),
+				},
+				Nonce:   hexToFelt("0x0"),
+				Version: new(felt.Felt).SetUint64(1),
+			},
+			network: utils.MAINNET,
+			want:    hexToFelt("0x546afb8137c8ce1724e9e2423f21e8000e4d2a50d59486d90be1e545643763e"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transactionHash, err := test.input.Hash(test.network)
+			if err != nil {
+				t.Errorf("no error expected but got %v", err)
+			}
+			if !transactionHash.Equal(test.want) {
+				t.Errorf("wrong hash: got %s, want %s", transactionHash.Text(16), test.want.Text(16))
+			}
+
+			checkTransactionSymmetry(t, &test.input)
+		})
+	}
+
+	t.Run("invalid version", func(t *testing.T) {
+		input := DeployAccountTransaction{Version: new(felt.Felt).SetUint64(2)}
+		_, err := input.Hash(utils.MAINNET)
+		assert.EqualError(t, err, "invalid transaction version")
+	})
+}
+
+func TestL1HandlerTransaction(t *testing.T) {
+	tests := map[string]struct {
+		input   L1HandlerTransaction
+		network utils.Network
+		want    *felt.Felt
+	}{
+		// https://alpha-mainnet.starknet.io/feeder_gateway/get_transaction?transactionHash=0x5d50b7020f7cf8033fd7d913e489f47edf74fbf3c8ada85be512c7baa6a2eab
+		"L1 handler transaction without nonce": {
+			input: L1HandlerTransaction{
+				ContractAddress:    hexToFelt("0x58b43819bb12aba8ab3fb2e997523e507399a3f48a1e2aa20a5fb7734a0449f"),
+				EntryPointSelector: hexToFelt("0xe3f5e9e1456ffa52a3fbc7e8c296631d4cc2120c0be1e2829301c0d8fa026b"),
+				CallData: [](*felt.Felt){
+					hexToFelt("0x5474c49483aa09993090979ade8101ebb4cdce4a"),
+					hexToFelt("0xabf8dd8438d1c21e83a8b5e9c1f9b58aaf3ed360"),
+					hexToFelt("0x2"),
+					hexToFelt("0x4c04fac82913f01a8f01f6e15ff7e834ff2d9a9a1d8e9adffc7bd45692f4f9a"),
+				},
+				Version: new(felt.Felt).SetUint64(0),
+			},
+			network: utils.MAINNET,
+			want:    hexToFelt("0x5d50b7020f7cf8033fd7d913e489f47edf74fbf3c8ada85be512c7baa6a2eab"),
+		},
+		"L1 handler transaction without version": {
+			input: L1HandlerTransaction{
+				ContractAddress:    hexToFelt("0x58b43819bb12aba8ab3fb2e997523e507399a3f48a1e2aa20a5fb7734a0449f"),
+				EntryPointSelector: hexToFelt("0xe3f5e9e1456ffa52a3fbc7e8c296631d4cc2120c0be1e2829301c0d8fa026b"),
+				CallData: [](*felt.Felt){
+					hexToFelt("0x5474c49483aa09993090979ade8101ebb4cdce4a"),
+					hexToFelt("0xabf8dd8438d1c21e83a8b5e9c1f9b58aaf3ed360"),
+					hexToFelt("0x2"),
+					hexToFelt("0x4c04fac82913f01a8f01f6e15ff7e834ff2d9a9a1d8e9adffc7bd45692f4f9a"),
+				},
+			},
+			network: utils.MAINNET,
+			want:    hexToFelt("0x5d50b7020f7cf8033fd7d913e489f47edf74fbf3c8ada85be512c7baa6a2eab"),
+		},
+		// https://alpha-mainnet.starknet.io/feeder_gateway/get_transaction?transactionHash=0x2186bb0c25bc56bd4de431cb4d2a8c2f2afe1ca113fa1f0c89a6078fde4b30f
+		"L1 handler transaction with nonce": {
+			input: L1HandlerTransaction{
+				ContractAddress:    hexToFelt("0x73314940630fd6dcda0d772d4c972c4e0a9946bef9dabf4ef84eda8ef542b82"),
+				EntryPointSelector: hexToFelt("0x2d757788a8d8d6f21d1cd40bce38a8222d70654214e96ff95d8086e684fbee5"),
+				Nonce:              hexToFelt("0x1f039"),
+				CallData: [](*felt.Felt){
+					hexToFelt("0xae0ee0a63a2ce6baeeffe56e7714fb4efe48d419"),
+					hexToFelt("0x2fd9fa8166918b2d359f5adb6d79ad5d668ccd59108108ce6ba907b45fe09a9"),
+					hexToFelt("0x16dedf44bdd8000"),
+					hexToFelt("0x0"),
+				},
+				Version: new(felt.Felt).SetUint64(0),
+			},
+			network: utils.MAINNET,
+			want:    hexToFelt("0x2186bb0c25bc56bd4de431cb4d2a8c2f2afe1ca113fa1f0c89a6078fde4b30f"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transactionHash, err := test.input.Hash(test.network)
+			if err != nil {
+				t.Errorf("no error expected but got %v", err)
+			}
+			if !transactionHash.Equal(test.want) {
+				t.Errorf("wrong hash: got %s, want %s", transactionHash.Text(16), test.want.Text(16))
+			}
+
+			checkTransactionSymmetry(t, &test.input)
+		})
+	}
+}
+
 func checkTransactionSymmetry(t *testing.T, input Transaction) {
 	data, err := encoder.Marshal(input)
 	assert.NoError(t, err)
@@ -211,6 +334,10 @@ func checkTransactionSymmetry(t *testing.T, input Transaction) {
 		assert.Equal(t, input, v)
 	case *InvokeTransaction:
 		assert.Equal(t, input, v)
+	case *DeployAccountTransaction:
+		assert.Equal(t, input, v)
+	case *L1HandlerTransaction:
+		assert.Equal(t, input, v)
 	default:
 		t.Error("not a transaction")
 	}
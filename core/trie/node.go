@@ -1,11 +1,10 @@
 package trie
 
 import (
-	"encoding/binary"
 	"fmt"
 
-	"github.com/NethermindEth/juno/core/crypto"
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/core/trie/proof"
 	"github.com/bits-and-blooms/bitset"
 )
 
@@ -26,28 +25,7 @@ type Node struct {
 
 // Hash calculates the hash of a [Node]
 func (n *Node) Hash(path *bitset.BitSet) *felt.Felt {
-	if path.Len() == 0 {
-		return n.Value
-	}
-
-	pathWords := path.Bytes()
-	if len(pathWords) > 4 {
-		panic("key too long to fit in Felt")
-	}
-
-	var pathBytes [32]byte
-	for idx, word := range pathWords {
-		startBytes := 24 - (idx * 8)
-		binary.BigEndian.PutUint64(pathBytes[startBytes:startBytes+8], word)
-	}
-
-	pathFelt := new(felt.Felt).SetBytes(pathBytes[:])
-
-	// https://docs.starknet.io/documentation/develop/State/starknet-state/
-	hash := crypto.Pedersen(n.Value, pathFelt)
-
-	pathFelt.SetUint64(uint64(path.Len()))
-	return hash.Add(hash, pathFelt)
+	return proof.HashEdge(n.Value, path)
 }
 
 // Equal checks for equality of two [Node]s
@@ -0,0 +1,53 @@
+package trie
+
+import (
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/core/trie/proof"
+	"github.com/bits-and-blooms/bitset"
+)
+
+// Prove returns the nodes on the path from the root of the [Trie] to key, starting with the
+// root. The hash of each node is committed to by the node before it and the hash of the first
+// node is the root of the [Trie].
+//
+// If key is not in the [Trie], the proof ends with a [proof.EdgeNode] whose path diverges from
+// key, proving that there is no value for key. An empty proof is returned for an empty [Trie].
+// Proofs are checked with [proof.Verify].
+func (t *Trie) Prove(key *felt.Felt) ([]proof.Node, error) {
+	nodeKey := t.FeltToBitSet(key)
+	nodes, err := t.nodesFromRoot(nodeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	proofNodes := make([]proof.Node, 0, 2*len(nodes))
+	var parentKey *bitset.BitSet
+	for _, cur := range nodes {
+		if path := Path(cur.key, parentKey); path.Len() > 0 {
+			proofNodes = append(proofNodes, proof.Node{Edge: &proof.EdgeNode{
+				Child: cur.node.Value,
+				Path:  path,
+			}})
+		}
+
+		// stop if this node is a leaf or key diverges from its path
+		if _, subset := FindCommonKey(nodeKey, cur.key); cur.node.Left == nil || cur.key.Len() >= nodeKey.Len() || !subset {
+			break
+		}
+
+		left, err := t.storage.Get(cur.node.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := t.storage.Get(cur.node.Right)
+		if err != nil {
+			return nil, err
+		}
+		proofNodes = append(proofNodes, proof.Node{Binary: &proof.BinaryNode{
+			LeftHash:  left.Hash(Path(cur.node.Left, cur.key)),
+			RightHash: right.Hash(Path(cur.node.Right, cur.key)),
+		}})
+		parentKey = cur.key
+	}
+	return proofNodes, nil
+}
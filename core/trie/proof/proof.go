@@ -0,0 +1,115 @@
+// Package proof verifies the proofs produced by [trie.Trie.Prove] without any database access,
+// so that it can be used without the storage of a node.
+//
+// [trie.Trie.Prove]: https://pkg.go.dev/github.com/NethermindEth/juno/core/trie#Trie.Prove
+package proof
+
+import (
+	"encoding/binary"
+
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/bits-and-blooms/bitset"
+)
+
+// Node is a node on the path from the root of a trie to a key. Exactly one of Binary and Edge
+// is set. See the [specification] for how nodes are committed to.
+//
+// [specification]: https://docs.starknet.io/documentation/develop/State/starknet-state/
+type Node struct {
+	Binary *BinaryNode
+	Edge   *EdgeNode
+}
+
+// BinaryNode is a node with two children, given by their hashes.
+type BinaryNode struct {
+	LeftHash  *felt.Felt
+	RightHash *felt.Felt
+}
+
+// EdgeNode is a path of Path.Len() bits leading to a child, given by its hash.
+type EdgeNode struct {
+	Child *felt.Felt
+	Path  *bitset.BitSet
+}
+
+// Hash calculates the commitment of a [Node].
+func (n *Node) Hash() *felt.Felt {
+	if n.Binary != nil {
+		return crypto.Pedersen(n.Binary.LeftHash, n.Binary.RightHash)
+	}
+	return HashEdge(n.Edge.Child, n.Edge.Path)
+}
+
+// HashEdge calculates the commitment of a path leading to a child with the given hash. The
+// commitment of an empty path is the hash of the child.
+func HashEdge(child *felt.Felt, path *bitset.BitSet) *felt.Felt {
+	if path.Len() == 0 {
+		return child
+	}
+
+	pathWords := path.Bytes()
+	if len(pathWords) > 4 {
+		panic("key too long to fit in Felt")
+	}
+
+	var pathBytes [32]byte
+	for idx, word := range pathWords {
+		startBytes := 24 - (idx * 8)
+		binary.BigEndian.PutUint64(pathBytes[startBytes:startBytes+8], word)
+	}
+
+	pathFelt := new(felt.Felt).SetBytes(pathBytes[:])
+
+	// https://docs.starknet.io/documentation/develop/State/starknet-state/
+	hash := crypto.Pedersen(child, pathFelt)
+
+	pathFelt.SetUint64(uint64(path.Len()))
+	return hash.Add(hash, pathFelt)
+}
+
+// Verify checks that proof proves that key has value in a trie of the given height with the
+// given root. Keys that are not in the trie have a zero value.
+func Verify(root, key, value *felt.Felt, proof []Node, height uint) bool {
+	if len(proof) == 0 {
+		return root.IsZero() && value.IsZero()
+	}
+
+	keyBits := key.Bits()
+	keyBitSet := bitset.FromWithLength(height, keyBits[:])
+	expected := root
+	depth := uint(0)
+	for i, node := range proof {
+		if (node.Binary == nil) == (node.Edge == nil) || depth >= height {
+			return false
+		}
+
+		if node.Binary != nil {
+			if node.Binary.LeftHash == nil || node.Binary.RightHash == nil || !node.Hash().Equal(expected) {
+				return false
+			}
+			if keyBitSet.Test(height - depth - 1) {
+				expected = node.Binary.RightHash
+			} else {
+				expected = node.Binary.LeftHash
+			}
+			depth++
+			continue
+		}
+
+		path := node.Edge.Path
+		if path == nil || node.Edge.Child == nil || path.Len() == 0 || depth+path.Len() > height ||
+			!node.Hash().Equal(expected) {
+			return false
+		}
+		for j := uint(0); j < path.Len(); j++ {
+			if path.Test(path.Len()-j-1) != keyBitSet.Test(height-depth-j-1) {
+				// key diverges from the path, so there is no value for key
+				return i == len(proof)-1 && value.IsZero()
+			}
+		}
+		expected = node.Edge.Child
+		depth += path.Len()
+	}
+	return depth == height && expected.Equal(value)
+}
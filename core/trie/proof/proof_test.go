@@ -0,0 +1,72 @@
+package proof_test
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/core/trie"
+	"github.com/NethermindEth/juno/core/trie/proof"
+	"github.com/bits-and-blooms/bitset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	const height = 251
+	key, value := new(felt.Felt).SetUint64(1), new(felt.Felt).SetUint64(10)
+
+	var root *felt.Felt
+	var nodes []proof.Node
+	require.NoError(t, trie.RunOnTempTrie(height, func(trie *trie.Trie) error {
+		for i := uint64(1); i <= 8; i++ {
+			_, err := trie.Put(new(felt.Felt).SetUint64(i), new(felt.Felt).SetUint64(i*10))
+			require.NoError(t, err)
+		}
+
+		var err error
+		root, err = trie.Root()
+		require.NoError(t, err)
+		nodes, err = trie.Prove(key)
+		require.NoError(t, err)
+		return nil
+	}))
+	require.True(t, proof.Verify(root, key, value, nodes, height))
+
+	t.Run("wrong root", func(t *testing.T) {
+		assert.False(t, proof.Verify(new(felt.Felt).SetUint64(44), key, value, nodes, height))
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		assert.False(t, proof.Verify(root, new(felt.Felt).SetUint64(2), value, nodes, height))
+	})
+
+	t.Run("wrong height", func(t *testing.T) {
+		assert.False(t, proof.Verify(root, key, value, nodes, height-1))
+	})
+
+	t.Run("truncated proof", func(t *testing.T) {
+		assert.False(t, proof.Verify(root, key, value, nodes[:len(nodes)-1], height))
+	})
+
+	t.Run("tampered binary node", func(t *testing.T) {
+		tampered := append([]proof.Node{}, nodes...)
+		for i, node := range tampered {
+			if node.Binary != nil {
+				tampered[i] = proof.Node{Binary: &proof.BinaryNode{LeftHash: node.Binary.RightHash, RightHash: node.Binary.LeftHash}}
+				break
+			}
+		}
+		assert.False(t, proof.Verify(root, key, value, tampered, height))
+	})
+
+	t.Run("malformed nodes", func(t *testing.T) {
+		for _, node := range []proof.Node{
+			{},
+			{Binary: &proof.BinaryNode{}, Edge: &proof.EdgeNode{}},
+			{Edge: &proof.EdgeNode{Child: value}},
+			{Edge: &proof.EdgeNode{Child: value, Path: bitset.New(height + 1)}},
+		} {
+			assert.False(t, proof.Verify(root, key, value, []proof.Node{node}, height))
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package trie
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/core/trie/proof"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProve(t *testing.T) {
+	const height = 251
+	keys := []*felt.Felt{
+		new(felt.Felt).SetUint64(1),
+		new(felt.Felt).SetUint64(2),
+		new(felt.Felt).SetUint64(3),
+		new(felt.Felt).SetUint64(0x100),
+	}
+
+	t.Run("empty trie", func(t *testing.T) {
+		require.NoError(t, RunOnTempTrie(height, func(trie *Trie) error {
+			nodes, err := trie.Prove(keys[0])
+			require.NoError(t, err)
+			assert.Empty(t, nodes)
+			assert.True(t, proof.Verify(new(felt.Felt), keys[0], new(felt.Felt), nodes, height))
+			assert.False(t, proof.Verify(new(felt.Felt), keys[0], new(felt.Felt).SetUint64(1), nodes, height))
+			return nil
+		}))
+	})
+
+	t.Run("single key", func(t *testing.T) {
+		require.NoError(t, RunOnTempTrie(height, func(trie *Trie) error {
+			value := new(felt.Felt).SetUint64(44)
+			_, err := trie.Put(keys[0], value)
+			require.NoError(t, err)
+			root, err := trie.Root()
+			require.NoError(t, err)
+
+			nodes, err := trie.Prove(keys[0])
+			require.NoError(t, err)
+			require.Len(t, nodes, 1)
+			require.NotNil(t, nodes[0].Edge)
+			assert.Equal(t, uint(height), nodes[0].Edge.Path.Len())
+			assert.True(t, proof.Verify(root, keys[0], value, nodes, height))
+
+			nodes, err = trie.Prove(keys[1])
+			require.NoError(t, err)
+			assert.True(t, proof.Verify(root, keys[1], new(felt.Felt), nodes, height))
+			return nil
+		}))
+	})
+
+	t.Run("multiple keys", func(t *testing.T) {
+		require.NoError(t, RunOnTempTrie(height, func(trie *Trie) error {
+			for i, key := range keys {
+				_, err := trie.Put(key, new(felt.Felt).SetUint64(uint64(i+10)))
+				require.NoError(t, err)
+			}
+			root, err := trie.Root()
+			require.NoError(t, err)
+
+			for i, key := range keys {
+				nodes, err := trie.Prove(key)
+				require.NoError(t, err)
+				assert.True(t, proof.Verify(root, key, new(felt.Felt).SetUint64(uint64(i+10)), nodes, height))
+				assert.False(t, proof.Verify(root, key, new(felt.Felt).SetUint64(uint64(i+11)), nodes, height))
+				assert.False(t, proof.Verify(root, key, new(felt.Felt), nodes, height))
+			}
+
+			// absent keys end with a diverging edge
+			for _, absent := range []*felt.Felt{new(felt.Felt).SetUint64(0x101), new(felt.Felt).SetUint64(0x8000)} {
+				nodes, err := trie.Prove(absent)
+				require.NoError(t, err)
+				require.NotEmpty(t, nodes)
+				assert.NotNil(t, nodes[len(nodes)-1].Edge)
+				assert.True(t, proof.Verify(root, absent, new(felt.Felt), nodes, height))
+				assert.False(t, proof.Verify(root, absent, new(felt.Felt).SetUint64(1), nodes, height))
+			}
+			return nil
+		}))
+	})
+}
@@ -8,9 +8,19 @@ import (
 	"github.com/NethermindEth/juno/core/crypto"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/metrics"
 	"github.com/bits-and-blooms/bitset"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+var puts = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "trie",
+	Name:      "puts_total",
+	Help:      "Number of values put into tries.",
+})
+
 // Storage is the Persistent storage for the [Trie]
 type Storage interface {
 	Put(key *bitset.BitSet, value *Node) error
@@ -153,6 +163,7 @@ func (t *Trie) Get(key *felt.Felt) (*felt.Felt, error) {
 
 // Put updates the corresponding `value` for a `key`
 func (t *Trie) Put(key *felt.Felt, value *felt.Felt) (*felt.Felt, error) {
+	puts.Inc()
 	// Todo: check key is not bigger than max key value for a trie height.
 	old := new(felt.Felt)
 	nodeKey := t.FeltToBitSet(key)
@@ -14,8 +14,20 @@ const (
 	ContractClassHash // maps contract addresses and class hashes
 	ContractStorage   // contract storages
 	ContractNonce     // contract nonce
-	HeadBlock         // Head of the blockchain
+	HeadBlock         // number of the head block
 	Blocks
+	StateUpdates                            // state updates by block number
+	BlockHashesByNumber                     // maps block numbers to block hashes
+	BlockNumbersByHash                      // maps block hashes to block numbers
+	TransactionBlockNumbersAndIndicesByHash // maps transaction hashes to block number and index
+	ContractEventBlocks                     // marks the blocks in which a contract emitted events
+	ReverseStateDiffs                       // values overwritten by each block's state update, by block number
+	ContractStorageHistory                  // storage values before they were changed, by address, key and block number
+	ContractNonceHistory                    // nonces before they were changed, by address and block number
+	ContractClassHashHistory                // class hashes before they were set, by address and block number
+	L1Head                                  // latest block whose state update was verified on L1
+	BlockStatuses                           // maps block numbers to block statuses
+	SchemaVersion                           // version of the database layout
 )
 
 // Key flattens a prefix and series of byte arrays into a single []byte.
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"github.com/NethermindEth/juno/metrics"
+	"github.com/dgraph-io/badger/v3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	lsmSizeDesc = prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "db", "lsm_size_bytes"),
+		"Size of the LSM tree of the database in bytes.", nil, nil)
+	vlogSizeDesc = prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "db", "vlog_size_bytes"),
+		"Size of the value log of the database in bytes.", nil, nil)
+)
+
+// sizeCollector reports the on-disk size of a Badger database.
+type sizeCollector struct {
+	badger *badger.DB
+}
+
+// NewSizeCollector returns a Prometheus collector reporting the size of database,
+// which must be created by this package.
+func NewSizeCollector(database DB) prometheus.Collector {
+	return &sizeCollector{badger: database.Impl().(*badger.DB)}
+}
+
+// Describe : see prometheus.Collector.Describe
+func (c *sizeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- lsmSizeDesc
+	ch <- vlogSizeDesc
+}
+
+// Collect : see prometheus.Collector.Collect
+func (c *sizeCollector) Collect(ch chan<- prometheus.Metric) {
+	lsm, vlog := c.badger.Size()
+	ch <- prometheus.MustNewConstMetric(lsmSizeDesc, prometheus.GaugeValue, float64(lsm))
+	ch <- prometheus.MustNewConstMetric(vlogSizeDesc, prometheus.GaugeValue, float64(vlog))
+}
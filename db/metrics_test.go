@@ -0,0 +1,19 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSizeCollector(t *testing.T) {
+	testDb := NewTestDb()
+	defer testDb.Close()
+
+	collector := NewSizeCollector(testDb)
+	assert.Equal(t, 2, testutil.CollectAndCount(collector))
+	problems, err := testutil.CollectAndLint(collector)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
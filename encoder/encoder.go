@@ -1,6 +1,7 @@
 package encoder
 
 import (
+	"encoding/binary"
 	"reflect"
 	"testing"
 
@@ -67,3 +68,11 @@ func TestSymmetry(t *testing.T, value any) {
 	assert.NoError(t, err)
 	assert.Equal(t, value, unmarshaled.Elem().Interface())
 }
+
+// Uint64Bytes returns the big-endian encoding of n, so that keys are
+// ordered by number in the database.
+func Uint64Bytes(n uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], n)
+	return b[:]
+}
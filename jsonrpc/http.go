@@ -0,0 +1,83 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	maxRequestBodySize = 10 * 1024 * 1024 // 10MB
+	readHeaderTimeout  = 5 * time.Second
+)
+
+// Http serves a JSON-RPC [Server] over HTTP POST requests.
+type Http struct {
+	rpc  *Server
+	http *http.Server
+}
+
+// NewHttp creates an HTTP transport for the given methods listening on port.
+func NewHttp(port uint16, methods []Method) (*Http, error) {
+	h := &Http{
+		rpc: NewServer(),
+	}
+	for _, method := range methods {
+		if err := h.rpc.RegisterMethod(method); err != nil {
+			return nil, err
+		}
+	}
+
+	h.http = &http.Server{
+		Addr:              net.JoinHostPort("", strconv.FormatUint(uint64(port), 10)),
+		Handler:           h,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	return h, nil
+}
+
+// Run starts listening for requests and blocks until [Http.Shutdown] is called.
+func (h *Http) Run() error {
+	if err := h.http.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown gracefully stops the server, waiting for in-flight requests
+// until ctx expires.
+func (h *Http) Shutdown(ctx context.Context) error {
+	return h.http.Shutdown(ctx)
+}
+
+// ServeHTTP processes an incoming HTTP request
+func (h *Http) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodGet {
+		writer.WriteHeader(http.StatusOK)
+		return
+	} else if req.Method != http.MethodPost {
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(writer, req.Body, maxRequestBodySize))
+	if err != nil {
+		writer.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	resp, err := h.rpc.Handle(body)
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if resp != nil {
+		_, _ = writer.Write(resp)
+	}
+}
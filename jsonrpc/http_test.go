@@ -0,0 +1,64 @@
+package jsonrpc_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttp(t *testing.T) {
+	methods := []jsonrpc.Method{
+		{
+			Name: "echo",
+			Params: []jsonrpc.Parameter{
+				{Name: "msg"},
+			},
+			Handler: func(msg string) (string, *jsonrpc.Error) { return msg, nil },
+		},
+	}
+
+	t.Run("invalid method", func(t *testing.T) {
+		_, err := jsonrpc.NewHttp(0, []jsonrpc.Method{{Name: "bad", Handler: 1}})
+		assert.Error(t, err)
+	})
+
+	server, err := jsonrpc.NewHttp(0, methods)
+	require.NoError(t, err)
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	t.Run("POST", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"jsonrpc" : "2.0", "method": "echo", "params": ["juno"], "id": 1}`)
+		res, err := http.Post(srv.URL, "application/json", body)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+		resBody, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"juno","id":1}`, string(resBody))
+	})
+
+	t.Run("GET", func(t *testing.T) {
+		res, err := http.Get(srv.URL)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("unsupported method", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPut, srv.URL, nil)
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+	})
+}
@@ -0,0 +1,300 @@
+// Package jsonrpc implements a transport agnostic [JSON-RPC 2.0] server.
+//
+// [JSON-RPC 2.0]: https://www.jsonrpc.org/specification
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	InvalidJSON    = -32700 // Invalid JSON was received by the server.
+	InvalidRequest = -32600 // The JSON sent is not a valid Request object.
+	MethodNotFound = -32601 // The method does not exist / is not available.
+	InvalidParams  = -32602 // Invalid method parameter(s).
+	InternalError  = -32603 // Internal JSON-RPC error.
+)
+
+const version = "2.0"
+
+type request struct {
+	Version string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Id      any             `json:"id,omitempty"`
+}
+
+type response struct {
+	Version string `json:"jsonrpc"`
+	Result  any    `json:"result,omitempty"`
+	Error   *Error `json:"error,omitempty"`
+	Id      any    `json:"id"`
+}
+
+// Error is a JSON-RPC error object, see https://www.jsonrpc.org/specification#error_object
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Err creates an [Error] with the given code and data. The message of the
+// [Error] is derived from the code for the error codes defined by the specification.
+func Err(code int, data any) *Error {
+	switch code {
+	case InvalidJSON:
+		return &Error{Code: InvalidJSON, Message: "Parse error", Data: data}
+	case InvalidRequest:
+		return &Error{Code: InvalidRequest, Message: "Invalid Request", Data: data}
+	case MethodNotFound:
+		return &Error{Code: MethodNotFound, Message: "Method Not Found", Data: data}
+	case InvalidParams:
+		return &Error{Code: InvalidParams, Message: "Invalid Params", Data: data}
+	default:
+		return &Error{Code: InternalError, Message: "Internal Error", Data: data}
+	}
+}
+
+func (r *request) isSane() error {
+	if r.Version != version {
+		return errors.New("unsupported RPC request version")
+	}
+	if len(r.Method) == 0 {
+		return errors.New("no method specified")
+	}
+
+	if params := bytes.TrimLeft(r.Params, " \t\r\n"); len(params) > 0 {
+		if params[0] != '[' && params[0] != '{' {
+			return errors.New("params should be an array or an object")
+		}
+	}
+
+	if r.Id != nil {
+		switch r.Id.(type) {
+		case float64, string:
+		default:
+			return errors.New("id should be a string or an integer")
+		}
+	}
+	return nil
+}
+
+// Parameter describes a single parameter of a [Method].
+type Parameter struct {
+	Name     string
+	Optional bool
+}
+
+// Method describes a JSON-RPC method. Handler must be a function whose
+// arguments correspond to Params, in order, and which returns exactly two
+// values: a result and an *[Error].
+type Method struct {
+	Name    string
+	Params  []Parameter
+	Handler any
+}
+
+// Server dispatches JSON-RPC requests to the registered [Method]s.
+type Server struct {
+	methods map[string]Method
+}
+
+// NewServer instantiates a JSON-RPC server with no registered methods.
+func NewServer() *Server {
+	return &Server{
+		methods: make(map[string]Method),
+	}
+}
+
+// RegisterMethod verifies and registers a [Method] with the server.
+func (s *Server) RegisterMethod(method Method) error {
+	handlerT := reflect.TypeOf(method.Handler)
+	if handlerT == nil || handlerT.Kind() != reflect.Func {
+		return errors.New("handler must be a function")
+	}
+	if handlerT.NumIn() != len(method.Params) {
+		return errors.New("number of function params and param names must match")
+	}
+	if handlerT.NumOut() != 2 {
+		return errors.New("handler must return 2 values")
+	}
+	if handlerT.Out(1) != reflect.TypeOf(&Error{}) {
+		return errors.New("second return value must be a *jsonrpc.Error")
+	}
+
+	s.methods[method.Name] = method
+	return nil
+}
+
+// Handle processes a request (or a batch of requests) and returns the
+// encoded response. A nil response is returned when no answer is expected,
+// i.e. the request only consisted of notifications.
+func (s *Server) Handle(data []byte) ([]byte, error) {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return json.Marshal(&response{Version: version, Error: Err(InvalidJSON, err.Error())})
+		}
+		if len(batch) == 0 {
+			return json.Marshal(&response{
+				Version: version,
+				Error:   Err(InvalidRequest, "empty batch"),
+			})
+		}
+
+		var responses []json.RawMessage
+		for _, rawReq := range batch {
+			res, err := s.handleRequest(rawReq)
+			if err != nil {
+				return nil, err
+			}
+			if res != nil {
+				responses = append(responses, res)
+			}
+		}
+		if len(responses) == 0 {
+			return nil, nil
+		}
+		return json.Marshal(responses)
+	}
+
+	return s.handleRequest(data)
+}
+
+func (s *Server) handleRequest(data []byte) ([]byte, error) {
+	req := new(request)
+	if err := json.Unmarshal(data, req); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return json.Marshal(&response{Version: version, Error: Err(InvalidJSON, err.Error())})
+		}
+		return json.Marshal(&response{Version: version, Error: Err(InvalidRequest, err.Error())})
+	}
+
+	if err := req.isSane(); err != nil {
+		return json.Marshal(&response{
+			Version: version,
+			Error:   Err(InvalidRequest, err.Error()),
+			Id:      req.Id,
+		})
+	}
+
+	res := &response{Version: version, Id: req.Id}
+	method, found := s.methods[req.Method]
+	if !found {
+		res.Error = Err(MethodNotFound, nil)
+	} else if args, err := s.buildArguments(req.Params, method); err != nil {
+		res.Error = Err(InvalidParams, err.Error())
+	} else {
+		results := reflect.ValueOf(method.Handler).Call(args)
+		if errVal := results[1]; !errVal.IsNil() {
+			res.Error = errVal.Interface().(*Error)
+		} else if result, err := json.Marshal(results[0].Interface()); err != nil {
+			res.Error = Err(InternalError, err.Error())
+		} else {
+			// result is encoded upfront so that zero values are not dropped by omitempty
+			res.Result = json.RawMessage(result)
+		}
+	}
+
+	// notifications don't get a response
+	if req.Id == nil {
+		return nil, nil
+	}
+	return json.Marshal(res)
+}
+
+func (s *Server) buildArguments(params json.RawMessage, method Method) ([]reflect.Value, error) {
+	handlerT := reflect.TypeOf(method.Handler)
+	args := make([]reflect.Value, 0, handlerT.NumIn())
+
+	if len(params) == 0 {
+		for _, param := range method.Params {
+			if !param.Optional {
+				return nil, fmt.Errorf("missing non-optional param %s", param.Name)
+			}
+		}
+		for i := 0; i < handlerT.NumIn(); i++ {
+			args = append(args, reflect.New(handlerT.In(i)).Elem())
+		}
+		return args, nil
+	}
+
+	switch bytes.TrimLeft(params, " \t\r\n")[0] {
+	case '[':
+		var positional []json.RawMessage
+		if err := json.Unmarshal(params, &positional); err != nil {
+			return nil, err
+		}
+		if len(positional) > len(method.Params) {
+			return nil, errors.New("too many params")
+		}
+
+		for i, param := range method.Params {
+			if i >= len(positional) {
+				if !param.Optional {
+					return nil, fmt.Errorf("missing non-optional param %s", param.Name)
+				}
+				args = append(args, reflect.New(handlerT.In(i)).Elem())
+				continue
+			}
+			arg, err := parseParam(positional[i], handlerT.In(i))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", param.Name, err)
+			}
+			args = append(args, arg)
+		}
+	case '{':
+		var named map[string]json.RawMessage
+		if err := json.Unmarshal(params, &named); err != nil {
+			return nil, err
+		}
+
+		for i, param := range method.Params {
+			raw, found := named[param.Name]
+			if !found {
+				if !param.Optional {
+					return nil, fmt.Errorf("missing non-optional param %s", param.Name)
+				}
+				args = append(args, reflect.New(handlerT.In(i)).Elem())
+				continue
+			}
+			arg, err := parseParam(raw, handlerT.In(i))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", param.Name, err)
+			}
+			args = append(args, arg)
+			delete(named, param.Name)
+		}
+
+		if len(named) > 0 {
+			unknown := make([]string, 0, len(named))
+			for name := range named {
+				unknown = append(unknown, name)
+			}
+			return nil, fmt.Errorf("unknown params: %s", strings.Join(unknown, ", "))
+		}
+	default:
+		return nil, errors.New("params should be an array or an object")
+	}
+	return args, nil
+}
+
+// parseParam decodes the raw JSON value into a new value of type t.
+func parseParam(raw json.RawMessage, t reflect.Type) (reflect.Value, error) {
+	value := reflect.New(t)
+	if err := json.Unmarshal(raw, value.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return value.Elem(), nil
+}
@@ -0,0 +1,165 @@
+package jsonrpc_test
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterMethod(t *testing.T) {
+	server := jsonrpc.NewServer()
+	tests := map[string]struct {
+		method jsonrpc.Method
+		want   string
+	}{
+		"handler is not a function": {
+			method: jsonrpc.Method{Name: "method", Handler: 44},
+			want:   "handler must be a function",
+		},
+		"param count mismatch": {
+			method: jsonrpc.Method{
+				Name:    "method",
+				Params:  []jsonrpc.Parameter{{Name: "a"}},
+				Handler: func() (int, *jsonrpc.Error) { return 0, nil },
+			},
+			want: "number of function params and param names must match",
+		},
+		"wrong number of return values": {
+			method: jsonrpc.Method{Name: "method", Handler: func() int { return 0 }},
+			want:   "handler must return 2 values",
+		},
+		"wrong error type": {
+			method: jsonrpc.Method{Name: "method", Handler: func() (int, error) { return 0, nil }},
+			want:   "second return value must be a *jsonrpc.Error",
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			assert.EqualError(t, server.RegisterMethod(test.method), test.want)
+		})
+	}
+}
+
+func TestHandle(t *testing.T) {
+	methods := []jsonrpc.Method{
+		{
+			Name:    "add",
+			Params:  []jsonrpc.Parameter{{Name: "a"}, {Name: "b"}},
+			Handler: func(a, b int) (int, *jsonrpc.Error) { return a + b, nil },
+		},
+		{
+			Name:   "greet",
+			Params: []jsonrpc.Parameter{{Name: "name", Optional: true}},
+			Handler: func(name string) (string, *jsonrpc.Error) {
+				if name == "" {
+					name = "stranger"
+				}
+				return "hello " + name, nil
+			},
+		},
+		{
+			Name: "fail",
+			Handler: func() (any, *jsonrpc.Error) {
+				return nil, &jsonrpc.Error{Code: 44, Message: "failed"}
+			},
+		},
+	}
+
+	server := jsonrpc.NewServer()
+	for _, method := range methods {
+		require.NoError(t, server.RegisterMethod(method))
+	}
+
+	tests := map[string]struct {
+		req  string
+		want string
+	}{
+		"invalid json": {
+			req:  `{"jsonrpc" : "2.0", "method": "add", "params": [1, 2], "id": 1`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32700,"message":"Parse error","data":"unexpected end of JSON input"},"id":null}`,
+		},
+		"wrong version": {
+			req:  `{"jsonrpc" : "1.0", "method": "add", "params": [1, 2], "id": 1}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request","data":"unsupported RPC request version"},"id":1}`,
+		},
+		"no method": {
+			req:  `{"jsonrpc" : "2.0", "params": [1, 2], "id": 1}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request","data":"no method specified"},"id":1}`,
+		},
+		"invalid id": {
+			req:  `{"jsonrpc" : "2.0", "method": "add", "params": [1, 2], "id": [1]}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request","data":"id should be a string or an integer"},"id":[1]}`,
+		},
+		"unknown method": {
+			req:  `{"jsonrpc" : "2.0", "method": "sub", "params": [1, 2], "id": 1}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method Not Found"},"id":1}`,
+		},
+		"zero result": {
+			req:  `{"jsonrpc" : "2.0", "method": "add", "params": [0, 0], "id": 1}`,
+			want: `{"jsonrpc":"2.0","result":0,"id":1}`,
+		},
+		"positional params": {
+			req:  `{"jsonrpc" : "2.0", "method": "add", "params": [1, 2], "id": 1}`,
+			want: `{"jsonrpc":"2.0","result":3,"id":1}`,
+		},
+		"named params": {
+			req:  `{"jsonrpc" : "2.0", "method": "add", "params": {"b": 2, "a": 1}, "id": "abc"}`,
+			want: `{"jsonrpc":"2.0","result":3,"id":"abc"}`,
+		},
+		"missing param": {
+			req:  `{"jsonrpc" : "2.0", "method": "add", "params": [1], "id": 1}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid Params","data":"missing non-optional param b"},"id":1}`,
+		},
+		"too many params": {
+			req:  `{"jsonrpc" : "2.0", "method": "add", "params": [1, 2, 3], "id": 1}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid Params","data":"too many params"},"id":1}`,
+		},
+		"unknown named param": {
+			req:  `{"jsonrpc" : "2.0", "method": "add", "params": {"a": 1, "b": 2, "c": 3}, "id": 1}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid Params","data":"unknown params: c"},"id":1}`,
+		},
+		"wrong param type": {
+			req:  `{"jsonrpc" : "2.0", "method": "add", "params": [1, "2"], "id": 1}`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid Params","data":"b: json: cannot unmarshal string into Go value of type int"},"id":1}`,
+		},
+		"optional param omitted": {
+			req:  `{"jsonrpc" : "2.0", "method": "greet", "id": 1}`,
+			want: `{"jsonrpc":"2.0","result":"hello stranger","id":1}`,
+		},
+		"optional param given": {
+			req:  `{"jsonrpc" : "2.0", "method": "greet", "params": ["juno"], "id": 1}`,
+			want: `{"jsonrpc":"2.0","result":"hello juno","id":1}`,
+		},
+		"handler error": {
+			req:  `{"jsonrpc" : "2.0", "method": "fail", "id": 1}`,
+			want: `{"jsonrpc":"2.0","error":{"code":44,"message":"failed"},"id":1}`,
+		},
+		"empty batch": {
+			req:  `[]`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request","data":"empty batch"},"id":null}`,
+		},
+		"batch": {
+			req: `[{"jsonrpc" : "2.0", "method": "add", "params": [1, 2], "id": 1},
+				{"jsonrpc" : "2.0", "method": "add", "params": [3, 4]},
+				{"jsonrpc" : "2.0", "method": "sub", "params": [1, 2], "id": 2}]`,
+			want: `[{"jsonrpc":"2.0","result":3,"id":1},{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method Not Found"},"id":2}]`,
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			res, err := server.Handle([]byte(test.req))
+			require.NoError(t, err)
+			assert.Equal(t, test.want, string(res))
+		})
+	}
+
+	t.Run("notification", func(t *testing.T) {
+		res, err := server.Handle([]byte(`{"jsonrpc" : "2.0", "method": "add", "params": [1, 2]}`))
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
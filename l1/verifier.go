@@ -0,0 +1,237 @@
+package l1
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	// maxBlockRange is the largest range of Ethereum blocks queried for logs at once,
+	// Ethereum nodes commonly reject larger ranges.
+	maxBlockRange = 2000
+	// maxLookback bounds how far back from the Ethereum head the latest state update
+	// is searched for on startup.
+	maxLookback = 100 * maxBlockRange
+	// confirmations is how many Ethereum blocks have to be built on top of the block a state
+	// update is logged in before it is read, so that Ethereum reorgs cannot undo it.
+	confirmations = 64
+)
+
+// logStateUpdateTopic is the topic of the LogStateUpdate(uint256 globalRoot, int256 blockNumber)
+// event the StarkNet core contract emits for every state update it verifies.
+var logStateUpdateTopic = crypto.Keccak256Hash([]byte("LogStateUpdate(uint256,int256)"))
+
+// EthClient is the subset of the Ethereum JSON-RPC API needed by the [Verifier].
+type EthClient interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// Verifier follows the state updates the StarkNet core contract verifies on Ethereum and
+// marks the stored blocks they refer to as accepted on L1.
+type Verifier struct {
+	running uint64
+
+	Blockchain *blockchain.Blockchain
+	client     EthClient
+	contract   common.Address
+	log        utils.Logger
+
+	pollInterval time.Duration
+
+	latest       atomic.Value // *blockchain.L1Head
+	nextEthBlock uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewVerifier creates a Verifier which polls the Ethereum JSON-RPC endpoint at ethNode
+// for new state updates of network every pollInterval.
+func NewVerifier(bc *blockchain.Blockchain, ethNode string, network utils.Network,
+	pollInterval time.Duration, log utils.Logger,
+) (*Verifier, error) {
+	client, err := ethclient.Dial(ethNode)
+	if err != nil {
+		return nil, err
+	}
+	return NewVerifierWithClient(bc, client, network.CoreContractAddress(), pollInterval, log), nil
+}
+
+// NewVerifierWithClient creates a Verifier which uses client to read the state updates
+// of the core contract deployed at contract.
+func NewVerifierWithClient(bc *blockchain.Blockchain, client EthClient, contract common.Address,
+	pollInterval time.Duration, log utils.Logger,
+) *Verifier {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Verifier{
+		Blockchain:   bc,
+		client:       client,
+		contract:     contract,
+		log:          log,
+		pollInterval: pollInterval,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// Run starts the Verifier, returns an error if it is already running
+func (v *Verifier) Run() error {
+	if running := atomic.CompareAndSwapUint64(&v.running, 0, 1); !running {
+		return errors.New("verifier is already running")
+	}
+	return v.FollowStateUpdates(v.ctx)
+}
+
+// FollowStateUpdates polls for new state updates every pollInterval until ctx is cancelled.
+func (v *Verifier) FollowStateUpdates(ctx context.Context) error {
+	for {
+		if err := v.Poll(ctx); err != nil && ctx.Err() == nil {
+			v.log.Warnw("Failed to verify the state on L1", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(v.pollInterval):
+		}
+	}
+}
+
+// Shutdown stops the Verifier, interrupting any request in flight.
+func (v *Verifier) Shutdown() error {
+	if stopped := atomic.CompareAndSwapUint64(&v.running, 1, 0); !stopped {
+		return errors.New("verifier is stopped")
+	}
+	v.cancel()
+	return nil
+}
+
+// LatestStateUpdate returns the latest state update verified on L1, whether or not the block
+// it refers to is stored yet. Nil is returned if no state update has been seen yet.
+func (v *Verifier) LatestStateUpdate() *blockchain.L1Head {
+	latest, _ := v.latest.Load().(*blockchain.L1Head)
+	return latest
+}
+
+// Poll reads the state updates logged since the last call in blocks with at least confirmations
+// Ethereum blocks on top of them, and records the latest one as the L1 head of the Blockchain
+// once the block it refers to is stored. Poll must not be called concurrently.
+func (v *Verifier) Poll(ctx context.Context) error {
+	ethHead, err := v.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	} else if ethHead < confirmations {
+		return nil
+	}
+	// the latest Ethereum block whose logs are read
+	confirmed := ethHead - confirmations
+
+	if v.nextEthBlock == 0 {
+		// search backwards for the latest state update on the first poll
+		for to := confirmed; v.LatestStateUpdate() == nil && to+maxLookback > confirmed; to -= maxBlockRange {
+			from := uint64(0)
+			if to >= maxBlockRange {
+				from = to - maxBlockRange + 1
+			}
+			if err = v.readStateUpdates(ctx, from, to); err != nil {
+				return err
+			}
+			if from == 0 {
+				break
+			}
+		}
+		v.nextEthBlock = confirmed + 1
+	}
+
+	for ; v.nextEthBlock <= confirmed; v.nextEthBlock += maxBlockRange {
+		to := v.nextEthBlock + maxBlockRange - 1
+		if to > confirmed {
+			to = confirmed
+		}
+		if err = v.readStateUpdates(ctx, v.nextEthBlock, to); err != nil {
+			return err
+		}
+	}
+	v.nextEthBlock = confirmed + 1
+
+	return v.verify()
+}
+
+// readStateUpdates keeps the last state update logged between the Ethereum blocks from and to.
+func (v *Verifier) readStateUpdates(ctx context.Context, from, to uint64) error {
+	logs, err := v.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{v.contract},
+		Topics:    [][]common.Hash{{logStateUpdateTopic}},
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := len(logs) - 1; i >= 0; i-- {
+		if logs[i].Removed {
+			continue
+		}
+		update, err := parseStateUpdate(&logs[i])
+		if err != nil {
+			return err
+		}
+		v.latest.Store(update)
+		return nil
+	}
+	return nil
+}
+
+// verify records the latest state update as the L1 head if its block is stored.
+func (v *Verifier) verify() error {
+	latest := v.LatestStateUpdate()
+	if latest == nil {
+		return nil
+	}
+
+	head, err := v.Blockchain.L1Head()
+	if err == nil && head.BlockNumber == latest.BlockNumber && head.StateRoot.Equal(latest.StateRoot) {
+		return nil
+	}
+
+	if err = v.Blockchain.SetL1Head(latest); errors.As(err, new(*blockchain.ErrBlockNotFound)) {
+		// the block is not synced yet
+		return nil
+	} else if err != nil {
+		return err
+	}
+	v.log.Infow("Verified block on L1", "number", latest.BlockNumber, "root", latest.StateRoot.Text(16))
+	return nil
+}
+
+// parseStateUpdate decodes the non-indexed arguments of a LogStateUpdate event, which are the
+// new global state root and the number of the block.
+func parseStateUpdate(stateUpdateLog *types.Log) (*blockchain.L1Head, error) {
+	if len(stateUpdateLog.Data) != 2*common.HashLength {
+		return nil, errors.New("malformed LogStateUpdate event")
+	}
+
+	blockNumber := new(big.Int).SetBytes(stateUpdateLog.Data[common.HashLength:])
+	// the block number is a signed integer, reject negative ones as well
+	if !blockNumber.IsUint64() {
+		return nil, errors.New("invalid block number in LogStateUpdate event")
+	}
+	return &blockchain.L1Head{
+		BlockNumber: blockNumber.Uint64(),
+		StateRoot:   new(felt.Felt).SetBytes(stateUpdateLog.Data[:common.HashLength]),
+	}, nil
+}
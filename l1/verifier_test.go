@@ -0,0 +1,190 @@
+package l1
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/starknetdata/gateway/gatewaytest"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEthNode serves the eth_blockNumber and eth_getLogs methods of the Ethereum JSON-RPC API
+// for a chain in which the core contract logged a state update in some of the blocks. The head
+// is moved so that the added state updates are confirmed.
+type fakeEthNode struct {
+	mu           sync.Mutex
+	head         uint64
+	stateUpdates map[uint64]*blockchain.L1Head // by Ethereum block number
+}
+
+func (f *fakeEthNode) addStateUpdate(ethBlock uint64, update *blockchain.L1Head) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stateUpdates[ethBlock] = update
+	if ethBlock+confirmations > f.head {
+		f.head = ethBlock + confirmations
+	}
+}
+
+func (f *fakeEthNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req struct {
+		Id     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var result any
+	switch req.Method {
+	case "eth_blockNumber":
+		result = hexutil.Uint64(f.head)
+	case "eth_getLogs":
+		var filter struct {
+			FromBlock hexutil.Uint64 `json:"fromBlock"`
+			ToBlock   hexutil.Uint64 `json:"toBlock"`
+			Address   []common.Address
+			Topics    [][]common.Hash
+		}
+		if err := json.Unmarshal(req.Params[0], &filter); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		logs := []map[string]any{}
+		for ethBlock := uint64(filter.FromBlock); ethBlock <= uint64(filter.ToBlock); ethBlock++ {
+			update, ok := f.stateUpdates[ethBlock]
+			if !ok {
+				continue
+			}
+			root := update.StateRoot.Bytes()
+			data := append(root[:], common.BigToHash(new(big.Int).SetUint64(update.BlockNumber)).Bytes()...)
+			logs = append(logs, map[string]any{
+				"address":         filter.Address[0],
+				"topics":          []common.Hash{filter.Topics[0][0]},
+				"data":            hexutil.Bytes(data),
+				"blockNumber":     hexutil.Uint64(ethBlock),
+				"transactionHash": common.Hash{},
+				"removed":         false,
+			})
+		}
+		result = logs
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.Id, "result": result})
+}
+
+func TestVerifier(t *testing.T) {
+	block0, stateUpdate0 := gatewaytest.MainnetBlock(t, 0)
+	block1, stateUpdate1 := gatewaytest.MainnetBlock(t, 1)
+
+	ethNode := &fakeEthNode{head: 3 * maxBlockRange, stateUpdates: make(map[uint64]*blockchain.L1Head)}
+	srv := httptest.NewServer(ethNode)
+	defer srv.Close()
+
+	chain := blockchain.NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+	verifier, err := NewVerifier(chain, srv.URL, utils.MAINNET, time.Millisecond, utils.NewNopZapLogger())
+	require.NoError(t, err)
+
+	t.Run("no state update", func(t *testing.T) {
+		require.NoError(t, verifier.Poll(verifier.ctx))
+		assert.Nil(t, verifier.LatestStateUpdate())
+	})
+
+	t.Run("state update is not confirmed yet", func(t *testing.T) {
+		ethNode.mu.Lock()
+		ethNode.stateUpdates[ethNode.head-confirmations+1] = &blockchain.L1Head{BlockNumber: 0, StateRoot: block0.GlobalStateRoot}
+		ethNode.mu.Unlock()
+
+		require.NoError(t, verifier.Poll(verifier.ctx))
+		assert.Nil(t, verifier.LatestStateUpdate())
+	})
+
+	t.Run("block is not synced yet", func(t *testing.T) {
+		ethNode.addStateUpdate(3*maxBlockRange+10, &blockchain.L1Head{BlockNumber: 0, StateRoot: block0.GlobalStateRoot})
+		require.NoError(t, verifier.Poll(verifier.ctx))
+		assert.Equal(t, &blockchain.L1Head{BlockNumber: 0, StateRoot: block0.GlobalStateRoot}, verifier.LatestStateUpdate())
+
+		_, err := chain.L1Head()
+		assert.ErrorIs(t, err, db.ErrKeyNotFound)
+	})
+
+	require.NoError(t, chain.Store(block0, stateUpdate0))
+	require.NoError(t, chain.Store(block1, stateUpdate1))
+
+	t.Run("synced block is verified", func(t *testing.T) {
+		require.NoError(t, verifier.Poll(verifier.ctx))
+		head, err := chain.L1Head()
+		require.NoError(t, err)
+		assert.Equal(t, &blockchain.L1Head{BlockNumber: 0, StateRoot: block0.GlobalStateRoot}, head)
+	})
+
+	t.Run("state root mismatch", func(t *testing.T) {
+		ethNode.addStateUpdate(3*maxBlockRange+20, &blockchain.L1Head{BlockNumber: 1, StateRoot: new(felt.Felt).SetUint64(44)})
+		assert.ErrorAs(t, verifier.Poll(verifier.ctx), new(*blockchain.ErrIncompatibleBlock))
+
+		head, err := chain.L1Head()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), head.BlockNumber)
+	})
+
+	t.Run("run until shut down", func(t *testing.T) {
+		// the latest state update is spread across multiple log queries
+		ethNode.addStateUpdate(5*maxBlockRange+30, &blockchain.L1Head{BlockNumber: 1, StateRoot: block1.GlobalStateRoot})
+
+		done := make(chan error)
+		go func() {
+			done <- verifier.Run()
+		}()
+		for {
+			if head, err := chain.L1Head(); err == nil && head.BlockNumber == 1 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		require.NoError(t, verifier.Shutdown())
+		assert.NoError(t, <-done)
+	})
+}
+
+func TestVerifierStartup(t *testing.T) {
+	block0, stateUpdate0 := gatewaytest.MainnetBlock(t, 0)
+	chain := blockchain.NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+	require.NoError(t, chain.Store(block0, stateUpdate0))
+
+	// the latest state update was logged a few block ranges before the Ethereum head
+	ethNode := &fakeEthNode{stateUpdates: map[uint64]*blockchain.L1Head{
+		100: {BlockNumber: 0, StateRoot: block0.GlobalStateRoot},
+	}, head: 5 * maxBlockRange}
+	srv := httptest.NewServer(ethNode)
+	defer srv.Close()
+
+	verifier, err := NewVerifier(chain, srv.URL, utils.MAINNET, time.Millisecond, utils.NewNopZapLogger())
+	require.NoError(t, err)
+	require.NoError(t, verifier.Poll(verifier.ctx))
+
+	head, err := chain.L1Head()
+	require.NoError(t, err)
+	assert.Equal(t, &blockchain.L1Head{BlockNumber: 0, StateRoot: block0.GlobalStateRoot}, head)
+}
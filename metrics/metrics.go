@@ -0,0 +1,53 @@
+// Package metrics serves the metrics collected by the other packages in the
+// Prometheus text format.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	Namespace = "juno"
+
+	metricsPath       = "/metrics"
+	readHeaderTimeout = 5 * time.Second
+)
+
+// Http serves the metrics registered with the default Prometheus registry on /metrics.
+type Http struct {
+	http *http.Server
+}
+
+// NewHttp creates an HTTP server for the metrics listening on addr.
+func NewHttp(addr string) *Http {
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{}))
+
+	return &Http{
+		http: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+// Run starts listening for requests and blocks until [Http.Shutdown] is called.
+func (h *Http) Run() error {
+	if err := h.http.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown gracefully stops the server, waiting for in-flight requests
+// until ctx expires.
+func (h *Http) Shutdown(ctx context.Context) error {
+	return h.http.Shutdown(ctx)
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttp(t *testing.T) {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "test_total",
+		Help:      "Counter used by the tests.",
+	})
+	require.NoError(t, prometheus.Register(counter))
+	defer prometheus.Unregister(counter)
+	counter.Add(3)
+
+	srv := httptest.NewServer(NewHttp("").http.Handler)
+	defer srv.Close()
+
+	t.Run("metrics", func(t *testing.T) {
+		res, err := http.Get(srv.URL + "/metrics")
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "juno_test_total 3")
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		res, err := http.Get(srv.URL + "/unknown")
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
@@ -1,17 +1,23 @@
 package node
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
 	"path/filepath"
+	stdsync "sync"
 	"time"
 
 	"github.com/NethermindEth/juno/blockchain"
 	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/l1"
+	"github.com/NethermindEth/juno/metrics"
+	"github.com/NethermindEth/juno/rpc"
 	"github.com/NethermindEth/juno/starknetdata/gateway"
 	"github.com/NethermindEth/juno/sync"
 	"github.com/NethermindEth/juno/utils"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type StarkNetNode interface {
@@ -34,19 +40,33 @@ var ErrUnknownNetwork = errors.New("unknown network")
 
 // Config is the top-level juno configuration.
 type Config struct {
-	Verbosity    string        `mapstructure:"verbosity"`
-	RpcPort      uint16        `mapstructure:"rpc-port"`
-	Metrics      bool          `mapstructure:"metrics"`
-	DatabasePath string        `mapstructure:"db-path"`
-	Network      utils.Network `mapstructure:"network"`
-	EthNode      string        `mapstructure:"eth-node"`
+	Verbosity       string        `mapstructure:"verbosity"`
+	LogFormat       string        `mapstructure:"log-format"`
+	RpcPort         uint16        `mapstructure:"rpc-port"`
+	Metrics         bool          `mapstructure:"metrics"`
+	DatabasePath    string        `mapstructure:"db-path"`
+	Network         utils.Network `mapstructure:"network"`
+	EthNode         string        `mapstructure:"eth-node"`
+	EthPollInterval time.Duration `mapstructure:"eth-poll-interval"`
+	SyncWorkers     uint          `mapstructure:"sync-workers"`
+	PollInterval    time.Duration `mapstructure:"poll-interval"`
 }
 
 type Node struct {
 	cfg          *Config
+	log          utils.Logger
 	db           db.DB
 	blockchain   *blockchain.Blockchain
 	synchronizer *sync.Synchronizer
+	verifier     *l1.Verifier
+	http         *jsonrpc.Http
+	metrics      *metrics.Http
+
+	// mu guards stopped and the servers, which are read by Shutdown while Run sets them.
+	mu      stdsync.Mutex
+	stopped bool
+	ctx     context.Context
+	cancel  context.CancelFunc
 }
 
 func New(cfg *Config) (StarkNetNode, error) {
@@ -60,11 +80,19 @@ func New(cfg *Config) (StarkNetNode, error) {
 		}
 		cfg.DatabasePath = filepath.Join(dirPrefix, cfg.Network.String())
 	}
-	return &Node{cfg: cfg}, nil
+
+	log, err := utils.NewZapLogger(cfg.Verbosity, cfg.LogFormat)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Node{cfg: cfg, log: log, ctx: ctx, cancel: cancel}, nil
 }
 
+// Run starts the components of the node and blocks until [Node.Shutdown] is called or one of
+// them fails. All the components are stopped before the database is closed.
 func (n *Node) Run() error {
-	log.Println("Running Juno with config: ", fmt.Sprintf("%+v", *n.cfg))
+	n.log.Infow("Running Juno", "config", *n.cfg)
 
 	var err error
 	n.db, err = db.NewDb(n.cfg.DatabasePath)
@@ -72,13 +100,125 @@ func (n *Node) Run() error {
 		return err
 	}
 	defer n.db.Close()
-	n.blockchain = blockchain.NewBlockchain(n.db, n.cfg.Network)
-	n.synchronizer = sync.NewSynchronizer(n.blockchain, gateway.NewGateway(n.cfg.Network))
-	return n.synchronizer.Run()
+	n.blockchain = blockchain.NewBlockchain(n.db, n.cfg.Network, n.log)
+	if err = n.blockchain.CheckSchemaVersion(); err != nil {
+		return err
+	}
+	n.synchronizer = sync.NewSynchronizer(n.blockchain, gateway.NewGateway(n.cfg.Network, n.log),
+		n.cfg.SyncWorkers, n.cfg.PollInterval, n.log)
+
+	if n.cfg.EthNode != "" {
+		n.verifier, err = l1.NewVerifier(n.blockchain, n.cfg.EthNode, n.cfg.Network, n.cfg.EthPollInterval, n.log)
+		if err != nil {
+			return err
+		}
+	}
+
+	var metricsHttp *metrics.Http
+	if n.cfg.Metrics {
+		dbSize := db.NewSizeCollector(n.db)
+		if err = prometheus.Register(dbSize); err != nil {
+			return err
+		}
+		defer prometheus.Unregister(dbSize)
+		metricsHttp = metrics.NewHttp(defaultMetricsPort)
+	}
+
+	rpcHandler := rpc.New(n.blockchain, n.synchronizer, n.cfg.Network)
+	rpcHttp, err := jsonrpc.NewHttp(n.cfg.RpcPort, rpcHandler.Methods())
+	if err != nil {
+		return err
+	}
+
+	var wg stdsync.WaitGroup
+	// the first component to fail stops the node, the errors of the ones failing
+	// while it stops are logged
+	componentErr := make(chan error, 1)
+	run := func(name string, runFn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if runErr := runFn(); runErr != nil {
+				select {
+				case componentErr <- fmt.Errorf("%s stopped: %w", name, runErr):
+				default:
+					n.log.Errorw(name+" stopped", "err", runErr)
+				}
+				n.cancel()
+			}
+		}()
+	}
+
+	n.mu.Lock()
+	if n.stopped {
+		n.mu.Unlock()
+		return nil
+	}
+	n.http, n.metrics = rpcHttp, metricsHttp
+	if n.verifier != nil {
+		run("L1 verifier", func() error {
+			return n.verifier.FollowStateUpdates(n.ctx)
+		})
+	}
+	if n.metrics != nil {
+		run("Metrics server", n.metrics.Run)
+	}
+	run("RPC server", n.http.Run)
+	n.mu.Unlock()
+
+	err = n.synchronizer.SyncBlocks(n.ctx)
+	shutdownErr := n.Shutdown()
+	wg.Wait()
+
+	select {
+	case runErr := <-componentErr:
+		err = combineErrors(err, runErr)
+	default:
+	}
+	return combineErrors(err, shutdownErr)
 }
 
+// Shutdown stops all the components of the node. The errors of the servers which could not
+// be stopped are logged and the first one is returned. Calling Shutdown more than once,
+// or before Run, does nothing.
 func (n *Node) Shutdown() error {
-	log.Println("Shutting down Juno...")
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.stopped {
+		return nil
+	}
+	n.stopped = true
+	n.log.Infow("Shutting down Juno...")
+	n.cancel()
 
-	return n.synchronizer.Shutdown()
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	var firstErr error
+	stopServer := func(name string, shutdownFn func(context.Context) error) {
+		if err := shutdownFn(ctx); err != nil {
+			n.log.Errorw("Failed to stop the "+name, "err", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if n.http != nil {
+		stopServer("RPC server", n.http.Shutdown)
+	}
+	if n.metrics != nil {
+		stopServer("metrics server", n.metrics.Shutdown)
+	}
+	return firstErr
+}
+
+// combineErrors returns the error combining err and other, either of which may be nil.
+// Only err can be matched with errors.Is and errors.As when both are set.
+func combineErrors(err, other error) error {
+	if err == nil {
+		return other
+	} else if other == nil {
+		return err
+	}
+	return fmt.Errorf("%w; %v", err, other)
 }
@@ -2,8 +2,10 @@ package node
 
 import (
 	"fmt"
+	"net"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/NethermindEth/juno/utils"
 	"github.com/stretchr/testify/assert"
@@ -31,6 +33,14 @@ func TestNew(t *testing.T) {
 		}
 	})
 
+	t.Run("logger", func(t *testing.T) {
+		_, err := New(&Config{Network: utils.MAINNET, Verbosity: "verbose"})
+		assert.Error(t, err)
+
+		_, err = New(&Config{Network: utils.MAINNET, LogFormat: "xml"})
+		assert.EqualError(t, err, "unknown log format: xml")
+	})
+
 	t.Run("default db-path", func(t *testing.T) {
 		defaultDataDir, err := utils.DefaultDataDir()
 		require.NoError(t, err)
@@ -54,3 +64,38 @@ func TestNew(t *testing.T) {
 		}
 	})
 }
+
+func TestRun(t *testing.T) {
+	t.Run("shut down before run", func(t *testing.T) {
+		node, err := New(&Config{Network: utils.MAINNET, DatabasePath: t.TempDir()})
+		require.NoError(t, err)
+
+		assert.NoError(t, node.Shutdown())
+		assert.NoError(t, node.Shutdown())
+		assert.NoError(t, node.Run())
+	})
+
+	t.Run("rpc port in use", func(t *testing.T) {
+		listener, err := net.Listen("tcp", ":0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		node, err := New(&Config{
+			Network:      utils.MAINNET,
+			DatabasePath: t.TempDir(),
+			RpcPort:      uint16(listener.Addr().(*net.TCPAddr).Port),
+		})
+		require.NoError(t, err)
+
+		done := make(chan error)
+		go func() {
+			done <- node.Run()
+		}()
+		select {
+		case err = <-done:
+			assert.ErrorContains(t, err, "RPC server stopped")
+		case <-time.After(10 * time.Second):
+			t.Fatal("node did not stop")
+		}
+	})
+}
@@ -0,0 +1,352 @@
+package rpc
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/sync"
+	"github.com/NethermindEth/juno/utils"
+)
+
+var (
+	ErrNoBlock          = &jsonrpc.Error{Code: 32, Message: "There are no blocks"}
+	ErrBlockNotFound    = &jsonrpc.Error{Code: 24, Message: "Block not found"}
+	ErrContractNotFound = &jsonrpc.Error{Code: 20, Message: "Contract not found"}
+)
+
+// Handler implements the read-only methods of the [StarkNet JSON-RPC specification].
+//
+// [StarkNet JSON-RPC specification]: https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json
+type Handler struct {
+	bcReader     *blockchain.Blockchain
+	synchronizer *sync.Synchronizer
+	network      utils.Network
+}
+
+func New(bcReader *blockchain.Blockchain, synchronizer *sync.Synchronizer, n utils.Network) *Handler {
+	return &Handler{
+		bcReader:     bcReader,
+		synchronizer: synchronizer,
+		network:      n,
+	}
+}
+
+// Methods returns the list of JSON-RPC methods served by the [Handler].
+func (h *Handler) Methods() []jsonrpc.Method {
+	return []jsonrpc.Method{
+		{
+			Name:    "starknet_chainId",
+			Handler: h.ChainId,
+		},
+		{
+			Name:    "starknet_blockNumber",
+			Handler: h.BlockNumber,
+		},
+		{
+			Name:    "starknet_blockHashAndNumber",
+			Handler: h.BlockNumberAndHash,
+		},
+		{
+			Name:    "starknet_getBlockWithTxHashes",
+			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
+			Handler: h.GetBlockWithTxHashes,
+		},
+		{
+			Name:    "starknet_getStateUpdate",
+			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
+			Handler: h.GetStateUpdate,
+		},
+		{
+			Name:    "starknet_getStorageAt",
+			Params:  []jsonrpc.Parameter{{Name: "contract_address"}, {Name: "key"}, {Name: "block_id"}},
+			Handler: h.GetStorageAt,
+		},
+		{
+			Name:    "starknet_syncing",
+			Handler: h.Syncing,
+		},
+	}
+}
+
+// ChainId returns the chain identifier of the network the node is connected to.
+//
+// It follows the specification defined here:
+// https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json
+func (h *Handler) ChainId() (*Felt, *jsonrpc.Error) {
+	return (*Felt)(h.network.ChainId()), nil
+}
+
+// BlockNumber returns the latest synced block number.
+//
+// It follows the specification defined here:
+// https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json
+func (h *Handler) BlockNumber() (uint64, *jsonrpc.Error) {
+	head, err := h.head()
+	if err != nil {
+		return 0, err
+	}
+	return head.Number, nil
+}
+
+// BlockNumberAndHash returns the block number and hash of the latest synced block.
+//
+// It follows the specification defined here:
+// https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json
+func (h *Handler) BlockNumberAndHash() (*BlockHashAndNumber, *jsonrpc.Error) {
+	head, err := h.head()
+	if err != nil {
+		return nil, err
+	}
+	return &BlockHashAndNumber{Number: head.Number, Hash: (*Felt)(head.Hash)}, nil
+}
+
+// GetBlockWithTxHashes returns the block information with transaction hashes given a block ID.
+//
+// It follows the specification defined here:
+// https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json
+func (h *Handler) GetBlockWithTxHashes(id BlockId) (*BlockWithTxHashes, *jsonrpc.Error) {
+	block, err := h.blockById(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	txnHashes := make([]*Felt, len(block.Receipts))
+	for i, receipt := range block.Receipts {
+		txnHashes[i] = (*Felt)(receipt.TransactionHash)
+	}
+
+	status, err := adaptBlockStatus(block.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockWithTxHashes{
+		Status:      status,
+		BlockHeader: adaptBlockHeader(block),
+		TxnHashes:   txnHashes,
+	}, nil
+}
+
+// GetStateUpdate returns the state update of the block identified by the given block ID.
+//
+// It follows the specification defined here:
+// https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json
+func (h *Handler) GetStateUpdate(id BlockId) (*StateUpdate, *jsonrpc.Error) {
+	if id.Pending {
+		pending, rpcErr := h.pending()
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		return adaptStateUpdate(pending.StateUpdate), nil
+	}
+
+	block, rpcErr := h.blockById(&id)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	update, err := h.bcReader.StateUpdateByNumber(block.Number)
+	if err != nil {
+		if errors.As(err, new(*blockchain.ErrBlockNotFound)) {
+			return nil, ErrBlockNotFound
+		}
+		return nil, jsonrpc.Err(jsonrpc.InternalError, err.Error())
+	}
+	return adaptStateUpdate(update), nil
+}
+
+// GetStorageAt returns the value of the storage slot at key of the contract at address,
+// as of the block identified by the given block ID.
+//
+// It follows the specification defined here:
+// https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json
+func (h *Handler) GetStorageAt(address, key *felt.Felt, id BlockId) (*Felt, *jsonrpc.Error) {
+	var value *felt.Felt
+	var err error
+	if id.Pending {
+		pending, rpcErr := h.pending()
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		value, err = h.bcReader.PendingContractStorage(pending.StateUpdate, address, key)
+	} else {
+		block, rpcErr := h.blockById(&id)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		value, err = h.bcReader.ContractStorageAt(address, key, block.Number)
+	}
+
+	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil, ErrContractNotFound
+		} else if errors.As(err, new(*blockchain.ErrBlockNotFound)) {
+			return nil, ErrBlockNotFound
+		}
+		return nil, jsonrpc.Err(jsonrpc.InternalError, err.Error())
+	}
+	return (*Felt)(value), nil
+}
+
+// Syncing returns the syncing status of the node.
+//
+// It follows the specification defined here:
+// https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json
+func (h *Handler) Syncing() (*Sync, *jsonrpc.Error) {
+	defaultSyncState := &Sync{Syncing: false}
+
+	startingBlockNumber, started := h.synchronizer.StartingBlockNumber()
+	highestBlockHeader := h.synchronizer.HighestBlockHeader()
+	if !started || highestBlockHeader == nil {
+		return defaultSyncState, nil
+	}
+
+	head, err := h.bcReader.Head()
+	if err != nil || head.Number >= highestBlockHeader.Number {
+		return defaultSyncState, nil
+	}
+
+	startingBlock, err := h.bcReader.BlockByNumber(startingBlockNumber)
+	if err != nil {
+		return defaultSyncState, nil
+	}
+
+	return &Sync{
+		Syncing:             true,
+		StartingBlockHash:   (*Felt)(startingBlock.Hash),
+		StartingBlockNumber: startingBlock.Number,
+		CurrentBlockHash:    (*Felt)(head.Hash),
+		CurrentBlockNumber:  head.Number,
+		HighestBlockHash:    (*Felt)(highestBlockHeader.Hash),
+		HighestBlockNumber:  highestBlockHeader.Number,
+	}, nil
+}
+
+func (h *Handler) head() (*core.Block, *jsonrpc.Error) {
+	head, err := h.bcReader.Head()
+	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil, ErrNoBlock
+		}
+		return nil, jsonrpc.Err(jsonrpc.InternalError, err.Error())
+	}
+	return head, nil
+}
+
+func (h *Handler) pending() (*sync.Pending, *jsonrpc.Error) {
+	pending := h.synchronizer.Pending()
+	if pending == nil {
+		return nil, ErrBlockNotFound
+	}
+	return pending, nil
+}
+
+func (h *Handler) blockById(id *BlockId) (*core.Block, *jsonrpc.Error) {
+	var block *core.Block
+	var err error
+	switch {
+	case id.Latest:
+		block, err = h.bcReader.Head()
+	case id.Hash != nil:
+		block, err = h.bcReader.BlockByHash(id.Hash)
+	case id.Pending:
+		pending, rpcErr := h.pending()
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		return pending.Block, nil
+	default:
+		block, err = h.bcReader.BlockByNumber(id.Number)
+	}
+
+	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) || errors.As(err, new(*blockchain.ErrBlockNotFound)) {
+			return nil, ErrBlockNotFound
+		}
+		return nil, jsonrpc.Err(jsonrpc.InternalError, err.Error())
+	}
+	return block, nil
+}
+
+func adaptBlockStatus(status core.BlockStatus) (BlockStatus, *jsonrpc.Error) {
+	switch status {
+	case core.StatusPending:
+		return StatusPending, nil
+	case core.StatusAcceptedOnL2:
+		return StatusAcceptedL2, nil
+	case core.StatusAcceptedOnL1:
+		return StatusAcceptedL1, nil
+	case core.StatusRejected:
+		return StatusRejected, nil
+	default:
+		return 0, jsonrpc.Err(jsonrpc.InternalError, "unknown block status: "+status.String())
+	}
+}
+
+func adaptBlockHeader(block *core.Block) BlockHeader {
+	var timestamp uint64
+	if block.Timestamp != nil {
+		timestamp = block.Timestamp.BigInt(new(big.Int)).Uint64()
+	}
+
+	return BlockHeader{
+		Hash:             (*Felt)(block.Hash),
+		ParentHash:       (*Felt)(block.ParentHash),
+		Number:           block.Number,
+		NewRoot:          (*Felt)(block.GlobalStateRoot),
+		Timestamp:        timestamp,
+		SequencerAddress: (*Felt)(block.SequencerAddress),
+	}
+}
+
+func adaptStateUpdate(update *core.StateUpdate) *StateUpdate {
+	stateDiff := &StateDiff{
+		StorageDiffs:           make([]StorageDiff, 0, len(update.StateDiff.StorageDiffs)),
+		DeclaredContractHashes: make([]*Felt, 0, len(update.StateDiff.DeclaredContracts)),
+		DeployedContracts:      make([]DeployedContract, 0, len(update.StateDiff.DeployedContracts)),
+		Nonces:                 make([]Nonce, 0, len(update.StateDiff.Nonces)),
+	}
+
+	for _, classHash := range update.StateDiff.DeclaredContracts {
+		stateDiff.DeclaredContractHashes = append(stateDiff.DeclaredContractHashes, (*Felt)(classHash))
+	}
+
+	for addr, diffs := range update.StateDiff.StorageDiffs {
+		addr := addr
+		entries := make([]Entry, 0, len(diffs))
+		for _, diff := range diffs {
+			entries = append(entries, Entry{Key: (*Felt)(diff.Key), Value: (*Felt)(diff.Value)})
+		}
+		stateDiff.StorageDiffs = append(stateDiff.StorageDiffs, StorageDiff{
+			Address:        (*Felt)(&addr),
+			StorageEntries: entries,
+		})
+	}
+
+	for _, deployedContract := range update.StateDiff.DeployedContracts {
+		stateDiff.DeployedContracts = append(stateDiff.DeployedContracts, DeployedContract{
+			Address:   (*Felt)(deployedContract.Address),
+			ClassHash: (*Felt)(deployedContract.ClassHash),
+		})
+	}
+
+	for addr, nonce := range update.StateDiff.Nonces {
+		addr := addr
+		stateDiff.Nonces = append(stateDiff.Nonces, Nonce{
+			ContractAddress: (*Felt)(&addr),
+			Nonce:           (*Felt)(nonce),
+		})
+	}
+
+	return &StateUpdate{
+		BlockHash: (*Felt)(update.BlockHash),
+		NewRoot:   (*Felt)(update.NewRoot),
+		OldRoot:   (*Felt)(update.OldRoot),
+		StateDiff: stateDiff,
+	}
+}
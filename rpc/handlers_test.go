@@ -0,0 +1,300 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/starknetdata/gateway/gatewaytest"
+	"github.com/NethermindEth/juno/sync"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHandler(t *testing.T, storeBlocks bool) (*rpc.Handler, []*core.Block, []*core.StateUpdate) {
+	block0, stateUpdate0 := gatewaytest.MainnetBlock(t, 0)
+	block1, stateUpdate1 := gatewaytest.MainnetBlock(t, 1)
+
+	chain := blockchain.NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+	if storeBlocks {
+		require.NoError(t, chain.Store(block0, stateUpdate0))
+		require.NoError(t, chain.Store(block1, stateUpdate1))
+	}
+	handler := rpc.New(chain, sync.NewSynchronizer(chain, nil, 1, 0, utils.NewNopZapLogger()), utils.MAINNET)
+	return handler, []*core.Block{block0, block1}, []*core.StateUpdate{stateUpdate0, stateUpdate1}
+}
+
+func TestChainId(t *testing.T) {
+	for _, n := range []utils.Network{utils.MAINNET, utils.GOERLI, utils.GOERLI2, utils.INTEGRATION} {
+		t.Run(n.String(), func(t *testing.T) {
+			handler := rpc.New(nil, nil, n)
+			cId, err := handler.ChainId()
+			assert.Nil(t, err)
+			assert.Equal(t, (*rpc.Felt)(n.ChainId()), cId)
+		})
+	}
+}
+
+func TestBlockNumber(t *testing.T) {
+	t.Run("empty blockchain", func(t *testing.T) {
+		handler, _, _ := newTestHandler(t, false)
+		num, err := handler.BlockNumber()
+		assert.Equal(t, uint64(0), num)
+		assert.Equal(t, rpc.ErrNoBlock, err)
+	})
+
+	t.Run("blocks", func(t *testing.T) {
+		handler, _, _ := newTestHandler(t, true)
+		num, err := handler.BlockNumber()
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(1), num)
+	})
+}
+
+func TestBlockNumberAndHash(t *testing.T) {
+	t.Run("empty blockchain", func(t *testing.T) {
+		handler, _, _ := newTestHandler(t, false)
+		block, err := handler.BlockNumberAndHash()
+		assert.Nil(t, block)
+		assert.Equal(t, rpc.ErrNoBlock, err)
+	})
+
+	t.Run("blocks", func(t *testing.T) {
+		handler, blocks, _ := newTestHandler(t, true)
+		block, err := handler.BlockNumberAndHash()
+		assert.Nil(t, err)
+		assert.Equal(t, &rpc.BlockHashAndNumber{Hash: (*rpc.Felt)(blocks[1].Hash), Number: 1}, block)
+	})
+}
+
+func TestGetBlockWithTxHashes(t *testing.T) {
+	handler, blocks, _ := newTestHandler(t, true)
+
+	checkBlock := func(t *testing.T, expected *core.Block, got *rpc.BlockWithTxHashes) {
+		assert.Equal(t, rpc.StatusAcceptedL1, got.Status)
+		assert.Equal(t, (*rpc.Felt)(expected.Hash), got.Hash)
+		assert.Equal(t, (*rpc.Felt)(expected.ParentHash), got.ParentHash)
+		assert.Equal(t, expected.Number, got.Number)
+		assert.Equal(t, (*rpc.Felt)(expected.GlobalStateRoot), got.NewRoot)
+		assert.Equal(t, (*rpc.Felt)(expected.SequencerAddress), got.SequencerAddress)
+		require.Equal(t, len(expected.Receipts), len(got.TxnHashes))
+		for i, receipt := range expected.Receipts {
+			assert.Equal(t, (*rpc.Felt)(receipt.TransactionHash), got.TxnHashes[i])
+		}
+	}
+
+	t.Run("latest", func(t *testing.T) {
+		block, err := handler.GetBlockWithTxHashes(rpc.BlockId{Latest: true})
+		require.Nil(t, err)
+		checkBlock(t, blocks[1], block)
+		assert.Equal(t, uint64(1637072695), block.Timestamp)
+	})
+
+	t.Run("by number", func(t *testing.T) {
+		block, err := handler.GetBlockWithTxHashes(rpc.BlockId{Number: 0})
+		require.Nil(t, err)
+		checkBlock(t, blocks[0], block)
+	})
+
+	t.Run("by hash", func(t *testing.T) {
+		block, err := handler.GetBlockWithTxHashes(rpc.BlockId{Hash: blocks[1].Hash})
+		require.Nil(t, err)
+		checkBlock(t, blocks[1], block)
+	})
+
+	t.Run("unknown number", func(t *testing.T) {
+		block, err := handler.GetBlockWithTxHashes(rpc.BlockId{Number: 2})
+		assert.Nil(t, block)
+		assert.Equal(t, rpc.ErrBlockNotFound, err)
+	})
+
+	t.Run("unknown hash", func(t *testing.T) {
+		block, err := handler.GetBlockWithTxHashes(rpc.BlockId{Hash: new(felt.Felt).SetUint64(44)})
+		assert.Nil(t, block)
+		assert.Equal(t, rpc.ErrBlockNotFound, err)
+	})
+
+	t.Run("no pending block", func(t *testing.T) {
+		block, err := handler.GetBlockWithTxHashes(rpc.BlockId{Pending: true})
+		assert.Nil(t, block)
+		assert.Equal(t, rpc.ErrBlockNotFound, err)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		chain := blockchain.NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+		block0, stateUpdate0 := gatewaytest.MainnetBlock(t, 0)
+		block1, stateUpdate1 := gatewaytest.MainnetBlock(t, 1)
+		block0.Status, block1.Status = core.StatusAcceptedOnL2, core.StatusAcceptedOnL2
+		require.NoError(t, chain.Store(block0, stateUpdate0))
+		require.NoError(t, chain.Store(block1, stateUpdate1))
+		handler := rpc.New(chain, sync.NewSynchronizer(chain, nil, 1, 0, utils.NewNopZapLogger()), utils.MAINNET)
+
+		assertStatus := func(t *testing.T, number uint64, want rpc.BlockStatus) {
+			block, err := handler.GetBlockWithTxHashes(rpc.BlockId{Number: number})
+			require.Nil(t, err)
+			assert.Equal(t, want, block.Status)
+		}
+		assertStatus(t, 0, rpc.StatusAcceptedL2)
+		assertStatus(t, 1, rpc.StatusAcceptedL2)
+
+		require.NoError(t, chain.SetL1Head(&blockchain.L1Head{BlockNumber: 0, StateRoot: block0.GlobalStateRoot}))
+		assertStatus(t, 0, rpc.StatusAcceptedL1)
+		assertStatus(t, 1, rpc.StatusAcceptedL2)
+
+		require.NoError(t, chain.SetBlockStatus(1, core.StatusRejected))
+		assertStatus(t, 1, rpc.StatusRejected)
+
+		require.NoError(t, chain.SetBlockStatus(1, core.StatusUnknown))
+		block, err := handler.GetBlockWithTxHashes(rpc.BlockId{Number: 1})
+		assert.Nil(t, block)
+		assert.Equal(t, jsonrpc.Err(jsonrpc.InternalError, "unknown block status: UNKNOWN"), err)
+	})
+}
+
+func TestGetStateUpdate(t *testing.T) {
+	handler, blocks, stateUpdates := newTestHandler(t, true)
+
+	for _, id := range []rpc.BlockId{{Latest: true}, {Number: 1}, {Hash: blocks[1].Hash}} {
+		update, err := handler.GetStateUpdate(id)
+		require.Nil(t, err)
+
+		expected := stateUpdates[1]
+		assert.Equal(t, (*rpc.Felt)(expected.BlockHash), update.BlockHash)
+		assert.Equal(t, (*rpc.Felt)(expected.NewRoot), update.NewRoot)
+		assert.Equal(t, (*rpc.Felt)(expected.OldRoot), update.OldRoot)
+		assert.Equal(t, len(expected.StateDiff.StorageDiffs), len(update.StateDiff.StorageDiffs))
+		for _, diff := range update.StateDiff.StorageDiffs {
+			expectedDiffs := expected.StateDiff.StorageDiffs[felt.Felt(*diff.Address)]
+			require.Equal(t, len(expectedDiffs), len(diff.StorageEntries))
+			for i, entry := range diff.StorageEntries {
+				assert.Equal(t, (*rpc.Felt)(expectedDiffs[i].Key), entry.Key)
+				assert.Equal(t, (*rpc.Felt)(expectedDiffs[i].Value), entry.Value)
+			}
+		}
+		require.Equal(t, len(expected.StateDiff.DeployedContracts), len(update.StateDiff.DeployedContracts))
+		for i, deployed := range update.StateDiff.DeployedContracts {
+			assert.Equal(t, (*rpc.Felt)(expected.StateDiff.DeployedContracts[i].Address), deployed.Address)
+			assert.Equal(t, (*rpc.Felt)(expected.StateDiff.DeployedContracts[i].ClassHash), deployed.ClassHash)
+		}
+		assert.Empty(t, update.StateDiff.Nonces)
+		assert.Empty(t, update.StateDiff.DeclaredContractHashes)
+	}
+
+	t.Run("unknown block", func(t *testing.T) {
+		update, err := handler.GetStateUpdate(rpc.BlockId{Number: 2})
+		assert.Nil(t, update)
+		assert.Equal(t, rpc.ErrBlockNotFound, err)
+	})
+
+	t.Run("no pending block", func(t *testing.T) {
+		update, err := handler.GetStateUpdate(rpc.BlockId{Pending: true})
+		assert.Nil(t, update)
+		assert.Equal(t, rpc.ErrBlockNotFound, err)
+	})
+}
+
+func TestGetStorageAt(t *testing.T) {
+	handler, blocks, _ := newTestHandler(t, true)
+
+	addr0, err := new(felt.Felt).SetString("0x20cfa74ee3564b4cd5435cdace0f9c4d43b939620e4a0bb5076105df0a626c6")
+	require.NoError(t, err)
+	addr1, err := new(felt.Felt).SetString("0x6538fdd3aa353af8a87f5fe77d1f533ea82815076e30a86d65b72d3eb4f0b80")
+	require.NoError(t, err)
+	key := new(felt.Felt).SetUint64(5)
+
+	t.Run("set slot", func(t *testing.T) {
+		for _, id := range []rpc.BlockId{{Latest: true}, {Number: 0}, {Hash: blocks[1].Hash}} {
+			value, rpcErr := handler.GetStorageAt(addr0, key, id)
+			require.Nil(t, rpcErr)
+			assert.Equal(t, (*rpc.Felt)(new(felt.Felt).SetUint64(0x22b)), value)
+		}
+	})
+
+	t.Run("unset slot", func(t *testing.T) {
+		value, rpcErr := handler.GetStorageAt(addr0, new(felt.Felt).SetUint64(44), rpc.BlockId{Latest: true})
+		require.Nil(t, rpcErr)
+		assert.Equal(t, (*rpc.Felt)(new(felt.Felt)), value)
+	})
+
+	t.Run("contract deployed in a later block", func(t *testing.T) {
+		value, rpcErr := handler.GetStorageAt(addr1, key, rpc.BlockId{Number: 0})
+		assert.Nil(t, value)
+		assert.Equal(t, rpc.ErrContractNotFound, rpcErr)
+
+		value, rpcErr = handler.GetStorageAt(addr1, key, rpc.BlockId{Number: 1})
+		require.Nil(t, rpcErr)
+		assert.Equal(t, (*rpc.Felt)(new(felt.Felt).SetUint64(0x22b)), value)
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		value, rpcErr := handler.GetStorageAt(addr0, key, rpc.BlockId{Number: 2})
+		assert.Nil(t, value)
+		assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
+	})
+
+	t.Run("no pending block", func(t *testing.T) {
+		value, rpcErr := handler.GetStorageAt(addr0, key, rpc.BlockId{Pending: true})
+		assert.Nil(t, value)
+		assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
+	})
+}
+
+func TestSyncing(t *testing.T) {
+	handler, _, _ := newTestHandler(t, true)
+
+	syncing, err := handler.Syncing()
+	assert.Nil(t, err)
+	assert.Equal(t, &rpc.Sync{Syncing: false}, syncing)
+
+	syncingJSON, jsonErr := json.Marshal(syncing)
+	require.NoError(t, jsonErr)
+	assert.Equal(t, "false", string(syncingJSON))
+}
+
+func TestFeltMarshal(t *testing.T) {
+	hashAndNumber := &rpc.BlockHashAndNumber{Hash: (*rpc.Felt)(new(felt.Felt).SetUint64(0x4437ab)), Number: 2}
+	hashAndNumberJSON, err := json.Marshal(hashAndNumber)
+	require.NoError(t, err)
+	assert.Equal(t, `{"block_hash":"0x4437ab","block_number":2}`, string(hashAndNumberJSON))
+
+	var unmarshalled rpc.BlockHashAndNumber
+	require.NoError(t, json.Unmarshal(hashAndNumberJSON, &unmarshalled))
+	assert.Equal(t, hashAndNumber, &unmarshalled)
+}
+
+func TestBlockIdUnmarshal(t *testing.T) {
+	tests := map[string]struct {
+		json string
+		want rpc.BlockId
+		err  bool
+	}{
+		"latest":  {json: `"latest"`, want: rpc.BlockId{Latest: true}},
+		"pending": {json: `"pending"`, want: rpc.BlockId{Pending: true}},
+		"number":  {json: `{"block_number": 123}`, want: rpc.BlockId{Number: 123}},
+		"hash": {
+			json: `{"block_hash": "0x123"}`,
+			want: rpc.BlockId{Hash: new(felt.Felt).SetUint64(0x123)},
+		},
+		"unknown tag":    {json: `"earliest"`, err: true},
+		"unknown object": {json: `{"block": 1}`, err: true},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			var id rpc.BlockId
+			err := json.Unmarshal([]byte(test.json), &id)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, id)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/NethermindEth/juno/core/felt"
+)
+
+// Felt is a field element that is encoded as a 0x-prefixed hex string, which is how felts
+// are represented in the specification.
+type Felt felt.Felt
+
+func (f *Felt) MarshalJSON() ([]byte, error) {
+	return []byte(`"0x` + (*felt.Felt)(f).Text(16) + `"`), nil
+}
+
+func (f *Felt) UnmarshalJSON(data []byte) error {
+	return (*felt.Felt)(f).UnmarshalJSON(data)
+}
+
+// BlockStatus is the status of a block as defined by the [specification].
+//
+// [specification]: https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json
+type BlockStatus uint8
+
+const (
+	StatusPending BlockStatus = iota
+	StatusAcceptedL2
+	StatusAcceptedL1
+	StatusRejected
+)
+
+func (s BlockStatus) MarshalJSON() ([]byte, error) {
+	switch s {
+	case StatusPending:
+		return []byte(`"PENDING"`), nil
+	case StatusAcceptedL2:
+		return []byte(`"ACCEPTED_ON_L2"`), nil
+	case StatusAcceptedL1:
+		return []byte(`"ACCEPTED_ON_L1"`), nil
+	case StatusRejected:
+		return []byte(`"REJECTED"`), nil
+	default:
+		return nil, errors.New("unknown block status")
+	}
+}
+
+// BlockId identifies a block either by its hash, its number or by one of the
+// "latest" and "pending" tags.
+type BlockId struct {
+	Pending bool
+	Latest  bool
+	Hash    *felt.Felt
+	Number  uint64
+}
+
+func (b *BlockId) UnmarshalJSON(data []byte) error {
+	if string(data) == `"latest"` {
+		b.Latest = true
+	} else if string(data) == `"pending"` {
+		b.Pending = true
+	} else {
+		jsonObject := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(data, &jsonObject); err != nil {
+			return err
+		}
+		hash, ok := jsonObject["block_hash"]
+		if ok {
+			b.Hash = new(felt.Felt)
+			return json.Unmarshal(hash, b.Hash)
+		}
+
+		number, ok := jsonObject["block_number"]
+		if ok {
+			return json.Unmarshal(number, &b.Number)
+		}
+
+		return errors.New("cannot unmarshal block id")
+	}
+	return nil
+}
+
+// BlockHashAndNumber is the result of starknet_blockHashAndNumber.
+type BlockHashAndNumber struct {
+	Hash   *Felt  `json:"block_hash"`
+	Number uint64 `json:"block_number"`
+}
+
+// BlockHeader contains the header fields of a block.
+type BlockHeader struct {
+	Hash             *Felt  `json:"block_hash"`
+	ParentHash       *Felt  `json:"parent_hash"`
+	Number           uint64 `json:"block_number"`
+	NewRoot          *Felt  `json:"new_root"`
+	Timestamp        uint64 `json:"timestamp"`
+	SequencerAddress *Felt  `json:"sequencer_address"`
+}
+
+// BlockWithTxHashes is a block header along with the hashes of its transactions.
+type BlockWithTxHashes struct {
+	Status BlockStatus `json:"status"`
+	BlockHeader
+	TxnHashes []*Felt `json:"transactions"`
+}
+
+// StateUpdate is the change in state applied by a block.
+type StateUpdate struct {
+	BlockHash *Felt      `json:"block_hash"`
+	NewRoot   *Felt      `json:"new_root"`
+	OldRoot   *Felt      `json:"old_root"`
+	StateDiff *StateDiff `json:"state_diff"`
+}
+
+// StateDiff is the change in state applied by a block, grouped by kind.
+type StateDiff struct {
+	StorageDiffs           []StorageDiff      `json:"storage_diffs"`
+	DeclaredContractHashes []*Felt            `json:"declared_contract_hashes"`
+	DeployedContracts      []DeployedContract `json:"deployed_contracts"`
+	Nonces                 []Nonce            `json:"nonces"`
+}
+
+type StorageDiff struct {
+	Address        *Felt   `json:"address"`
+	StorageEntries []Entry `json:"storage_entries"`
+}
+
+type Entry struct {
+	Key   *Felt `json:"key"`
+	Value *Felt `json:"value"`
+}
+
+type DeployedContract struct {
+	Address   *Felt `json:"address"`
+	ClassHash *Felt `json:"class_hash"`
+}
+
+type Nonce struct {
+	ContractAddress *Felt `json:"contract_address"`
+	Nonce           *Felt `json:"nonce"`
+}
+
+// Sync is the result of starknet_syncing.
+type Sync struct {
+	Syncing             bool   `json:"-"`
+	StartingBlockHash   *Felt  `json:"starting_block_hash"`
+	StartingBlockNumber uint64 `json:"starting_block_num"`
+	CurrentBlockHash    *Felt  `json:"current_block_hash"`
+	CurrentBlockNumber  uint64 `json:"current_block_num"`
+	HighestBlockHash    *Felt  `json:"highest_block_hash"`
+	HighestBlockNumber  uint64 `json:"highest_block_num"`
+}
+
+// MarshalJSON encodes the [Sync] status as `false` when the node is not syncing,
+// as required by the specification.
+func (s Sync) MarshalJSON() ([]byte, error) {
+	if !s.Syncing {
+		return []byte("false"), nil
+	}
+
+	type syncStatus Sync // avoid infinite recursion
+	return json.Marshal(syncStatus(s))
+}
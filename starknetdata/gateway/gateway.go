@@ -1,11 +1,14 @@
 package gateway
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"github.com/NethermindEth/juno/clients"
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/starknetdata"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -14,28 +17,70 @@ type Gateway struct {
 	client *clients.GatewayClient
 }
 
-func NewGateway(n utils.Network) *Gateway {
+func NewGateway(n utils.Network, log utils.Logger) *Gateway {
 	return &Gateway{
-		client: clients.NewGatewayClient(n.URL()),
+		client: clients.NewGatewayClient(n.URL()).WithLogger(log),
 	}
 }
 
 // BlockByNumber gets the block for a given block number from the feeder gateway,
 // then adapts it to the core.Block type.
-func (g *Gateway) BlockByNumber(blockNumber uint64) (*core.Block, error) {
-	response, err := g.client.GetBlock(blockNumber)
+func (g *Gateway) BlockByNumber(ctx context.Context, blockNumber uint64) (*core.Block, error) {
+	response, err := g.client.GetBlock(ctx, blockNumber)
 	if err != nil {
-		return nil, err
+		return nil, adaptError(err)
+	}
+
+	return AdaptBlock(response)
+}
+
+// LatestBlock gets the latest block accepted by the sequencer from the feeder gateway.
+func (g *Gateway) LatestBlock(ctx context.Context) (*core.Block, error) {
+	response, err := g.client.GetLatestBlock(ctx)
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return AdaptBlock(response)
+}
+
+// PendingBlock gets the block the sequencer is currently building from the feeder gateway,
+// then adapts it to the core.Block type. The hash, number and state root of the pending
+// block are not set.
+func (g *Gateway) PendingBlock(ctx context.Context) (*core.Block, error) {
+	response, err := g.client.GetPendingBlock(ctx)
+	if err != nil {
+		return nil, adaptError(err)
 	}
 
 	return AdaptBlock(response)
 }
 
+// adaptError maps the client errors the rest of the node needs to tell apart
+// to their [starknetdata] equivalents.
+func adaptError(err error) error {
+	if clients.IsBlockNotFound(err) {
+		return starknetdata.ErrBlockNotFound
+	} else if clients.IsTransient(err) {
+		return fmt.Errorf("%w: %v", starknetdata.ErrUnavailable, err)
+	}
+	return err
+}
+
 func AdaptBlock(response *clients.Block) (*core.Block, error) {
 	if response == nil {
 		return nil, nil
 	}
 
+	// Transactions
+	txs := make([]core.Transaction, len(response.Transactions))
+	for i, tx := range response.Transactions {
+		var err error
+		if txs[i], err = adaptTransaction(tx); err != nil {
+			return nil, err
+		}
+	}
+
 	// Receipts
 	receipts := make([]*core.TransactionReceipt, len(response.Receipts))
 	var txType core.TransactionType
@@ -85,9 +130,29 @@ func AdaptBlock(response *clients.Block) (*core.Block, error) {
 		EventCommitment:       eventCommitment,
 		ProtocolVersion:       new(felt.Felt),
 		ExtraData:             nil,
+		Transactions:          txs,
+		Receipts:              receipts,
+		Status:                adaptBlockStatus(response.Status),
 	}, nil
 }
 
+// adaptBlockStatus adapts the status of a block. Statuses which are not known, such as
+// ones introduced by newer versions of the sequencer, are adapted to [core.StatusUnknown].
+func adaptBlockStatus(status string) core.BlockStatus {
+	switch status {
+	case "PENDING":
+		return core.StatusPending
+	case "ACCEPTED_ON_L2":
+		return core.StatusAcceptedOnL2
+	case "ACCEPTED_ON_L1":
+		return core.StatusAcceptedOnL1
+	case "REJECTED":
+		return core.StatusRejected
+	default:
+		return core.StatusUnknown
+	}
+}
+
 func adaptTransactionReceipt(response *clients.TransactionReceipt,
 	txType core.TransactionType, signature []*felt.Felt,
 ) *core.TransactionReceipt {
@@ -181,10 +246,10 @@ func adaptL2ToL1Message(response *clients.L2ToL1Message) *core.L2ToL1Message {
 
 // Transaction gets the transaction for a given transaction hash from the feeder gateway,
 // then adapts it to the appropriate core.Transaction types.
-func (g *Gateway) Transaction(transactionHash *felt.Felt) (core.Transaction, error) {
-	response, err := g.client.GetTransaction(transactionHash)
+func (g *Gateway) Transaction(ctx context.Context, transactionHash *felt.Felt) (core.Transaction, error) {
+	response, err := g.client.GetTransaction(ctx, transactionHash)
 	if err != nil {
-		return nil, err
+		return nil, adaptError(err)
 	}
 
 	tx, err := adaptTransaction(response.Transaction)
@@ -210,11 +275,17 @@ func adaptTransaction(transaction *clients.Transaction) (core.Transaction, error
 			return nil, err
 		}
 		return deployTx, nil
-	case "INVOKE":
+	case "INVOKE_FUNCTION":
 		invokeTx := adaptInvokeTransaction(transaction)
 		return invokeTx, nil
+	case "DEPLOY_ACCOUNT":
+		deployAccountTx := adaptDeployAccountTransaction(transaction)
+		return deployAccountTx, nil
+	case "L1_HANDLER":
+		l1HandlerTx := adaptL1HandlerTransaction(transaction)
+		return l1HandlerTx, nil
 	default:
-		return nil, nil
+		return nil, errors.New("unknown transaction")
 	}
 }
 
@@ -233,6 +304,7 @@ func adaptDeclareTransaction(transaction *clients.Transaction) (*core.DeclareTra
 func adaptDeployTransaction(transaction *clients.Transaction) (*core.DeployTransaction, error) {
 	deployTx := new(core.DeployTransaction)
 	deployTx.ContractAddressSalt = transaction.ContractAddressSalt
+	deployTx.ContractAddress = transaction.ContractAddress
 	deployTx.ConstructorCallData = transaction.ConstructorCalldata
 	deployTx.CallerAddress = transaction.ContractAddress
 	deployTx.Version = transaction.Version
@@ -255,12 +327,37 @@ func adaptInvokeTransaction(transaction *clients.Transaction) *core.InvokeTransa
 	return invokeTx
 }
 
+func adaptDeployAccountTransaction(transaction *clients.Transaction) *core.DeployAccountTransaction {
+	deployAccountTx := new(core.DeployAccountTransaction)
+	deployAccountTx.ContractAddressSalt = transaction.ContractAddressSalt
+	deployAccountTx.ContractAddress = transaction.ContractAddress
+	deployAccountTx.ClassHash = transaction.ClassHash
+	deployAccountTx.ConstructorCallData = transaction.ConstructorCalldata
+	deployAccountTx.MaxFee = transaction.MaxFee
+	deployAccountTx.Signature = transaction.Signature
+	deployAccountTx.Nonce = transaction.Nonce
+	deployAccountTx.Version = transaction.Version
+
+	return deployAccountTx
+}
+
+func adaptL1HandlerTransaction(transaction *clients.Transaction) *core.L1HandlerTransaction {
+	l1HandlerTx := new(core.L1HandlerTransaction)
+	l1HandlerTx.ContractAddress = transaction.ContractAddress
+	l1HandlerTx.EntryPointSelector = transaction.EntryPointSelector
+	l1HandlerTx.Nonce = transaction.Nonce
+	l1HandlerTx.CallData = transaction.Calldata
+	l1HandlerTx.Version = transaction.Version
+
+	return l1HandlerTx
+}
+
 // GetClass gets the class for a given class hash from the feeder gateway,
 // then adapts it to the core.Class type.
-func (g *Gateway) Class(classHash *felt.Felt) (*core.Class, error) {
-	response, err := g.client.GetClassDefinition(classHash)
+func (g *Gateway) Class(ctx context.Context, classHash *felt.Felt) (*core.Class, error) {
+	response, err := g.client.GetClassDefinition(ctx, classHash)
 	if err != nil {
-		return nil, err
+		return nil, adaptError(err)
 	}
 
 	return adaptClass(response)
@@ -313,10 +410,21 @@ func adaptClass(response *clients.ClassDefinition) (*core.Class, error) {
 
 // StateUpdate gets the state update for a given block number from the feeder gateway,
 // then adapts it to the core.StateUpdate type.
-func (g *Gateway) StateUpdate(blockNumber uint64) (*core.StateUpdate, error) {
-	response, err := g.client.GetStateUpdate(blockNumber)
+func (g *Gateway) StateUpdate(ctx context.Context, blockNumber uint64) (*core.StateUpdate, error) {
+	response, err := g.client.GetStateUpdate(ctx, blockNumber)
 	if err != nil {
-		return nil, err
+		return nil, adaptError(err)
+	}
+
+	return AdaptStateUpdate(response)
+}
+
+// PendingStateUpdate gets the state update of the pending block from the feeder gateway,
+// then adapts it to the core.StateUpdate type. Its block hash and new root are not set.
+func (g *Gateway) PendingStateUpdate(ctx context.Context) (*core.StateUpdate, error) {
+	response, err := g.client.GetPendingStateUpdate(ctx)
+	if err != nil {
+		return nil, adaptError(err)
 	}
 
 	return AdaptStateUpdate(response)
@@ -1,12 +1,17 @@
 package gateway
 
 import (
+	"context"
 	_ "embed"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/NethermindEth/juno/clients"
+	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/starknetdata"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +29,10 @@ var (
 	deployJson []byte
 	//go:embed testdata/declareTx_0x6eab8252abfc9bbfd72c8d592dde4018d07ce467c5ce922519d7142fcab203f.json
 	declareJson []byte
+	//go:embed testdata/deployAccountTx_0x546afb8137c8ce1724e9e2423f21e8000e4d2a50d59486d90be1e545643763e.json
+	deployAccountJson []byte
+	//go:embed testdata/l1HandlerTx_0x2186bb0c25bc56bd4de431cb4d2a8c2f2afe1ca113fa1f0c89a6078fde4b30f.json
+	l1HandlerJson []byte
 )
 
 func TestAdaptBlock(t *testing.T) {
@@ -44,6 +53,12 @@ func TestAdaptBlock(t *testing.T) {
 		assert.Equal(t, new(felt.Felt).SetUint64(uint64(len(response.Transactions))), block.TransactionCount)
 		assert.Equal(t, new(felt.Felt), block.ProtocolVersion)
 		assert.Nil(t, block.ExtraData)
+		assert.Equal(t, core.StatusAcceptedOnL2, block.Status)
+		require.Equal(t, len(response.Transactions), len(block.Transactions))
+		require.Equal(t, len(response.Receipts), len(block.Receipts))
+		for i, receipt := range block.Receipts {
+			assert.True(t, receipt.TransactionHash.Equal(response.Receipts[i].TransactionHash))
+		}
 		// TODO test transaction commitment...?
 		// TODO test event commitment and count
 	})
@@ -62,6 +77,12 @@ func TestAdaptBlock(t *testing.T) {
 		assert.Equal(t, new(felt.Felt).SetUint64(uint64(len(response.Transactions))), block.TransactionCount)
 		assert.Equal(t, new(felt.Felt), block.ProtocolVersion)
 		assert.Nil(t, block.ExtraData)
+		assert.Equal(t, core.StatusAcceptedOnL1, block.Status)
+		require.Equal(t, len(response.Transactions), len(block.Transactions))
+		require.Equal(t, len(response.Receipts), len(block.Receipts))
+		for i, receipt := range block.Receipts {
+			assert.True(t, receipt.TransactionHash.Equal(response.Receipts[i].TransactionHash))
+		}
 		// TODO test transaction commitment...?
 		// TODO test event commitment and count
 	})
@@ -75,6 +96,16 @@ func TestAdaptBlock(t *testing.T) {
 		assert.Nil(t, block)
 		assert.EqualError(t, err, "unknown transaction")
 	})
+	t.Run("unknown status", func(t *testing.T) {
+		err := json.Unmarshal(block147Json, &response)
+		require.NoError(t, err)
+
+		response.Status = "ABORTED"
+
+		block, err := AdaptBlock(response)
+		require.NoError(t, err)
+		assert.Equal(t, core.StatusUnknown, block.Status)
+	})
 }
 
 func TestAdaptStateUpdate(t *testing.T) {
@@ -262,3 +293,67 @@ func TestAdaptDeclareTransaction(t *testing.T) {
 	assert.Equal(t, transaction.Signature, declareTx.Signature)
 	assert.Equal(t, transaction.ClassHash, declareTx.ClassHash)
 }
+
+func TestAdaptDeployAccountTransaction(t *testing.T) {
+	response := new(clients.TransactionStatus)
+	err := json.Unmarshal(deployAccountJson, response)
+	assert.NoError(t, err)
+
+	transaction := response.Transaction
+	deployAccountTx := adaptDeployAccountTransaction(transaction)
+	assert.Equal(t, transaction.ContractAddressSalt, deployAccountTx.ContractAddressSalt)
+	assert.Equal(t, transaction.ContractAddress, deployAccountTx.ContractAddress)
+	assert.Equal(t, transaction.ClassHash, deployAccountTx.ClassHash)
+	assert.Equal(t, transaction.ConstructorCalldata, deployAccountTx.ConstructorCallData)
+	assert.Equal(t, transaction.MaxFee, deployAccountTx.MaxFee)
+	assert.Equal(t, transaction.Signature, deployAccountTx.Signature)
+	assert.Equal(t, transaction.Nonce, deployAccountTx.Nonce)
+	assert.Equal(t, transaction.Version, deployAccountTx.Version)
+}
+
+func TestAdaptL1HandlerTransaction(t *testing.T) {
+	response := new(clients.TransactionStatus)
+	err := json.Unmarshal(l1HandlerJson, response)
+	assert.NoError(t, err)
+
+	transaction := response.Transaction
+	l1HandlerTx := adaptL1HandlerTransaction(transaction)
+	assert.Equal(t, transaction.ContractAddress, l1HandlerTx.ContractAddress)
+	assert.Equal(t, transaction.EntryPointSelector, l1HandlerTx.EntryPointSelector)
+	assert.Equal(t, transaction.Nonce, l1HandlerTx.Nonce)
+	assert.Equal(t, transaction.Calldata, l1HandlerTx.CallData)
+	assert.Equal(t, transaction.Version, l1HandlerTx.Version)
+}
+
+func TestBlockNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code": "StarknetErrorCode.BLOCK_NOT_FOUND", "message": "Block number 44 was not found."}`))
+	}))
+	defer srv.Close()
+	gateway := &Gateway{client: clients.NewGatewayClient(srv.URL)}
+
+	block, err := gateway.BlockByNumber(context.Background(), 44)
+	assert.Nil(t, block)
+	assert.ErrorIs(t, err, starknetdata.ErrBlockNotFound)
+
+	update, err := gateway.StateUpdate(context.Background(), 44)
+	assert.Nil(t, update)
+	assert.ErrorIs(t, err, starknetdata.ErrBlockNotFound)
+}
+
+func TestUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	gateway := &Gateway{client: clients.NewGatewayClient(srv.URL).WithMaxRetries(0)}
+
+	block, err := gateway.BlockByNumber(context.Background(), 44)
+	assert.Nil(t, block)
+	assert.ErrorIs(t, err, starknetdata.ErrUnavailable)
+
+	update, err := gateway.PendingStateUpdate(context.Background())
+	assert.Nil(t, update)
+	assert.ErrorIs(t, err, starknetdata.ErrUnavailable)
+}
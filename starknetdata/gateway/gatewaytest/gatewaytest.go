@@ -0,0 +1,39 @@
+// Package gatewaytest provides mainnet blocks and state updates, as served by the feeder gateway
+// and adapted by package gateway, for use in tests.
+package gatewaytest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/NethermindEth/juno/clients"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/starknetdata/gateway"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/*.json
+var testdata embed.FS
+
+// MainnetBlock returns the mainnet block with the given number and its state update. Blocks 0
+// to 2 are available, the test fails for other numbers.
+func MainnetBlock(t *testing.T, number uint64) (*core.Block, *core.StateUpdate) {
+	t.Helper()
+
+	rawBlock, err := testdata.ReadFile(fmt.Sprintf("testdata/mainnet_block_%d.json", number))
+	require.NoError(t, err)
+	rawStateUpdate, err := testdata.ReadFile(fmt.Sprintf("testdata/mainnet_state_update_%d.json", number))
+	require.NoError(t, err)
+
+	clientBlock, clientStateUpdate := new(clients.Block), new(clients.StateUpdate)
+	require.NoError(t, json.Unmarshal(rawBlock, clientBlock))
+	require.NoError(t, json.Unmarshal(rawStateUpdate, clientStateUpdate))
+
+	block, err := gateway.AdaptBlock(clientBlock)
+	require.NoError(t, err)
+	stateUpdate, err := gateway.AdaptStateUpdate(clientStateUpdate)
+	require.NoError(t, err)
+	return block, stateUpdate
+}
@@ -1,14 +1,28 @@
 package starknetdata
 
 import (
+	"context"
+	"errors"
+
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
 )
 
+// ErrBlockNotFound is returned when the requested block does not exist yet,
+// i.e. it is beyond the tip of the chain.
+var ErrBlockNotFound = errors.New("block not found")
+
+// ErrUnavailable is returned when the data source cannot be reached for now,
+// e.g. during an outage, and the request should be tried again later.
+var ErrUnavailable = errors.New("starknet data is unavailable")
+
 // StarkNetData defines the function which are required to retrieve StarkNet's state
 type StarkNetData interface {
-	BlockByNumber(blockNumber uint64) (*core.Block, error)
-	Transaction(transactionHash *felt.Felt) (core.Transaction, error)
-	Class(classHash *felt.Felt) (*core.Class, error)
-	StateUpdate(blockNumber uint64) (*core.StateUpdate, error)
+	BlockByNumber(ctx context.Context, blockNumber uint64) (*core.Block, error)
+	LatestBlock(ctx context.Context) (*core.Block, error)
+	Transaction(ctx context.Context, transactionHash *felt.Felt) (core.Transaction, error)
+	Class(ctx context.Context, classHash *felt.Felt) (*core.Class, error)
+	StateUpdate(ctx context.Context, blockNumber uint64) (*core.StateUpdate, error)
+	PendingBlock(ctx context.Context) (*core.Block, error)
+	PendingStateUpdate(ctx context.Context) (*core.StateUpdate, error)
 }
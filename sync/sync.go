@@ -1,12 +1,50 @@
 package sync
 
 import (
+	"context"
 	"errors"
-	"log"
 	"sync/atomic"
+	"time"
 
 	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/metrics"
 	"github.com/NethermindEth/juno/starknetdata"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// statusRefreshDepth is how many blocks below and including the head have their status
+	// refreshed while at the tip of the chain.
+	statusRefreshDepth = 16
+	// statusRefreshInterval is how often the statuses are refreshed while at the tip of the chain.
+	statusRefreshInterval = time.Minute
+	// latestBlockPollInterval is how often the latest block known to StarkNetData is polled.
+	latestBlockPollInterval = 30 * time.Second
+)
+
+var (
+	syncHeight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "sync",
+		Name:      "height",
+		Help:      "Number of the latest stored block.",
+	})
+	highestBlock = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "sync",
+		Name:      "highest_block",
+		Help:      "Number of the highest block known to StarkNetData.",
+	})
+	blocksStored = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "sync",
+		Name:      "blocks_stored_total",
+		Help:      "Number of blocks stored, its rate is the number of blocks synced per second.",
+	})
 )
 
 // Synchronizer manages a list of StarkNetData to fetch the latest blockchain updates
@@ -15,16 +53,37 @@ type Synchronizer struct {
 
 	Blockchain   *blockchain.Blockchain
 	StarkNetData starknetdata.StarkNetData
+	log          utils.Logger
 
-	quit chan struct{}
+	workers      int
+	pollInterval time.Duration
+
+	startingBlockNumber atomic.Value // uint64
+	highestBlockHeader  atomic.Value // *core.Block
+	pending             atomic.Value // *Pending
+
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
-func NewSynchronizer(bc *blockchain.Blockchain, starkNetData starknetdata.StarkNetData) *Synchronizer {
+// NewSynchronizer creates a Synchronizer which fetches up to workers blocks concurrently and,
+// once it reaches the tip of the chain, polls for new blocks every pollInterval.
+func NewSynchronizer(bc *blockchain.Blockchain, starkNetData starknetdata.StarkNetData, workers uint,
+	pollInterval time.Duration, log utils.Logger,
+) *Synchronizer {
+	if workers == 0 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Synchronizer{
 		running:      0,
 		Blockchain:   bc,
 		StarkNetData: starkNetData,
-		quit:         make(chan struct{}),
+		log:          log,
+		workers:      int(workers),
+		pollInterval: pollInterval,
+		ctx:          ctx,
+		cancel:       cancel,
 	}
 }
 
@@ -33,49 +92,366 @@ func (s *Synchronizer) Run() error {
 	if running := atomic.CompareAndSwapUint64(&s.running, 0, 1); !running {
 		return errors.New("synchronizer is already running")
 	}
-	return s.SyncBlocks()
+	return s.SyncBlocks(s.ctx)
 }
 
-// Shutdown attempts to stop the Synchronizer, should block until loop acknowledges the request
+// Shutdown attempts to stop the Synchronizer by cancelling the context of the loop,
+// which also interrupts any request to StarkNetData in flight.
 func (s *Synchronizer) Shutdown() error {
 	if stopped := atomic.CompareAndSwapUint64(&s.running, 1, 0); !stopped {
 		return errors.New("synchronizer is stopped")
 	}
-	close(s.quit)
+	s.cancel()
 	return nil
 }
 
-func (s *Synchronizer) SyncBlocks() error {
+// StartingBlockNumber returns the number of the first block fetched by the
+// Synchronizer. False is returned if syncing has not started yet.
+func (s *Synchronizer) StartingBlockNumber() (uint64, bool) {
+	number, ok := s.startingBlockNumber.Load().(uint64)
+	return number, ok
+}
+
+// HighestBlockHeader returns the header of the latest block known to StarkNetData, which is
+// polled while syncing and raised by the blocks fetched in between. Nil is returned if it is
+// not known yet.
+func (s *Synchronizer) HighestBlockHeader() *core.Block {
+	header, _ := s.highestBlockHeader.Load().(*core.Block)
+	return header
+}
+
+// Pending is the block the sequencer is currently building on top of the head, along with
+// its state update. The number of the block is set, its hash and state root are not known yet.
+type Pending struct {
+	Block       *core.Block
+	StateUpdate *core.StateUpdate
+}
+
+// Pending returns the pending block on top of the head. Nil is returned if there is
+// none, which is always the case while catching up with the chain.
+func (s *Synchronizer) Pending() *Pending {
+	pending, _ := s.pending.Load().(*Pending)
+	return pending
+}
+
+// SyncBlocks fetches blocks and their state updates from StarkNetData and stores them in the
+// Blockchain until ctx is cancelled or an error occurs.
+//
+// When the next block does not exist yet, SyncBlocks fetches the pending block, refreshes the
+// statuses of the latest blocks and waits for pollInterval before asking for it again. It
+// waits the same way when StarkNetData is unavailable rather than returning.
+//
+// Up to workers blocks ahead of the head are fetched and verified concurrently, while they are
+// stored in order. If a fetched block is not a child of the head, the head is assumed to be
+// replaced by a reorg: it is reverted and syncing restarts from its parent.
+func (s *Synchronizer) SyncBlocks(ctx context.Context) error {
+	var startingBlockNumber uint64
+	if h := s.Blockchain.Height(); h != nil {
+		startingBlockNumber = *h + 1
+		syncHeight.Set(float64(*h))
+	}
+	s.startingBlockNumber.Store(startingBlockNumber)
+
+	ctx, cancel := context.WithCancel(ctx)
+	s.updateLatestBlock(ctx)
+	pollerDone := make(chan struct{})
+	go func() {
+		defer close(pollerDone)
+		s.pollLatestBlock(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-pollerDone
+	}()
+
+	var lastStatusRefresh time.Time
 	for {
-		select {
-		case <-s.quit:
-			// Clean up and return
+		atTip, err := s.syncFromHead(ctx)
+		if ctx.Err() != nil {
 			return nil
-		default:
-			// Call the gateway to get blocks and state update
-			var nextHeight uint64
-			if h := s.Blockchain.Height(); h != nil {
-				nextHeight = *h + 1
+		} else if errors.Is(err, starknetdata.ErrUnavailable) {
+			// StarkNetData already backed off before giving up, keep waiting for it to
+			// come back instead of stopping the node.
+			s.log.Warnw("StarkNet data is unavailable, retrying", "err", err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(s.pollInterval):
 			}
-			block, err := s.StarkNetData.BlockByNumber(nextHeight)
-			if err != nil {
-				return err
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if atTip {
+			if err = s.updatePending(ctx); err != nil && ctx.Err() == nil {
+				s.log.Warnw("Failed to fetch the pending block", "err", err)
 			}
-			log.Println()
-			log.Printf("Fetched Block: Number: %d, Hash: %s", block.Number, block.Hash.Text(16))
-			stateUpdate, err := s.StarkNetData.StateUpdate(nextHeight)
-			if err != nil {
-				return err
+			if time.Since(lastStatusRefresh) >= statusRefreshInterval {
+				if err = s.refreshStatuses(ctx); err != nil && ctx.Err() == nil {
+					s.log.Warnw("Failed to refresh block statuses", "err", err)
+				}
+				lastStatusRefresh = time.Now()
 			}
-			log.Printf("Fetched StateUpdate: Hash: %s, NewRoot: %s", stateUpdate.BlockHash.Text(16),
-				stateUpdate.NewRoot.Text(16))
-			if err = s.Blockchain.Store(block, stateUpdate); err != nil {
-				return err
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(s.pollInterval):
 			}
-			log.Printf("Stored Block: Number: %d, Hash: %s", block.Number, block.Hash.Text(16))
-			log.Printf("Applied StateUpdate: Hash: %s, NewRoot: %s",
-				stateUpdate.BlockHash.Text(16),
-				stateUpdate.NewRoot.Text(16))
 		}
 	}
 }
+
+type fetchJob struct {
+	height uint64
+	result chan<- fetchResult
+}
+
+type fetchResult struct {
+	block       *core.Block
+	stateUpdate *core.StateUpdate
+	err         error
+}
+
+// syncFromHead runs the fetching pipeline starting from the block after the head and
+// stores the fetched blocks in order. It returns when the head is reverted, or with true
+// when the next block does not exist yet.
+func (s *Synchronizer) syncFromHead(ctx context.Context) (bool, error) {
+	head, err := s.Blockchain.Head()
+	if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
+		return false, err
+	}
+	var nextHeight uint64
+	if head != nil {
+		nextHeight = head.Number + 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// pending holds the results of the dispatched jobs in block order, its capacity
+	// bounds how far ahead of the head blocks are fetched.
+	pending := make(chan chan fetchResult, s.workers)
+	jobs := make(chan fetchJob)
+	for i := 0; i < s.workers; i++ {
+		go s.fetchWorker(ctx, jobs)
+	}
+	go func() {
+		defer close(jobs)
+		for height := nextHeight; ; height++ {
+			result := make(chan fetchResult, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- fetchJob{height: height, result: result}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		var res fetchResult
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case result := <-pending:
+			select {
+			case <-ctx.Done():
+				return false, nil
+			case res = <-result:
+			}
+		}
+		if errors.Is(res.err, starknetdata.ErrBlockNotFound) {
+			return true, nil
+		} else if res.err != nil {
+			return false, res.err
+		}
+
+		block, stateUpdate := res.block, res.stateUpdate
+		if head != nil && !block.ParentHash.Equal(head.Hash) {
+			// The head block has been replaced, walk back until the common ancestor
+			s.log.Warnw("Reorg detected, reverting the head", "number", head.Number, "hash", head.Hash.Text(16),
+				"child", block.Number, "childParentHash", block.ParentHash.Text(16))
+			s.pending.Store((*Pending)(nil))
+			if err = s.Blockchain.RevertHead(); err != nil {
+				return false, err
+			}
+			syncHeight.Set(float64(head.Number) - 1)
+			return false, nil
+		}
+
+		// the block hash has been verified by the worker which fetched it
+		if err = s.Blockchain.StoreVerified(block, stateUpdate); err != nil {
+			return false, err
+		}
+		s.pending.Store((*Pending)(nil))
+		head = block
+		syncHeight.Set(float64(block.Number))
+		blocksStored.Inc()
+		s.log.Infow("Stored block", "number", block.Number, "hash", block.Hash.Text(16),
+			"root", stateUpdate.NewRoot.Text(16))
+	}
+}
+
+// fetchWorker fetches and verifies the blocks and state updates requested on jobs until
+// jobs is closed.
+func (s *Synchronizer) fetchWorker(ctx context.Context, jobs <-chan fetchJob) {
+	for job := range jobs {
+		if err := ctx.Err(); err != nil {
+			job.result <- fetchResult{err: err}
+			continue
+		}
+		job.result <- s.fetch(ctx, job.height)
+	}
+}
+
+func (s *Synchronizer) fetch(ctx context.Context, height uint64) fetchResult {
+	start := time.Now()
+	block, err := s.StarkNetData.BlockByNumber(ctx, height)
+	if err != nil {
+		return fetchResult{err: err}
+	}
+	s.raiseHighestBlockHeader(block)
+	s.log.Debugw("Fetched block", "number", block.Number, "hash", block.Hash.Text(16),
+		"duration", time.Since(start))
+
+	if err = s.Blockchain.VerifyBlockHash(block); err != nil {
+		return fetchResult{err: err}
+	}
+
+	start = time.Now()
+	stateUpdate, err := s.StarkNetData.StateUpdate(ctx, height)
+	if err != nil {
+		return fetchResult{err: err}
+	}
+	s.log.Debugw("Fetched state update", "number", height, "root", stateUpdate.NewRoot.Text(16),
+		"duration", time.Since(start))
+	return fetchResult{block: block, stateUpdate: stateUpdate}
+}
+
+// pollLatestBlock updates the latest block known to StarkNetData every latestBlockPollInterval
+// until ctx is cancelled.
+func (s *Synchronizer) pollLatestBlock(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(latestBlockPollInterval):
+			s.updateLatestBlock(ctx)
+		}
+	}
+}
+
+func (s *Synchronizer) updateLatestBlock(ctx context.Context) {
+	latest, err := s.StarkNetData.LatestBlock(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.Warnw("Failed to fetch the latest block", "err", err)
+		}
+		return
+	}
+	s.highestBlockHeader.Store(latest)
+	highestBlock.Set(float64(latest.Number))
+}
+
+// raiseHighestBlockHeader makes block the highest block header unless a higher one is known.
+func (s *Synchronizer) raiseHighestBlockHeader(block *core.Block) {
+	for {
+		highest := s.highestBlockHeader.Load()
+		if highest != nil && highest.(*core.Block).Number >= block.Number {
+			return
+		} else if s.highestBlockHeader.CompareAndSwap(highest, block) {
+			highestBlock.Set(float64(block.Number))
+			return
+		}
+	}
+}
+
+// updatePending fetches the pending block and its state update, and keeps them if they
+// are built on top of the head.
+func (s *Synchronizer) updatePending(ctx context.Context) error {
+	head, err := s.Blockchain.Head()
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	block, err := s.StarkNetData.PendingBlock(ctx)
+	if err != nil {
+		s.pending.Store((*Pending)(nil))
+		if errors.Is(err, starknetdata.ErrBlockNotFound) {
+			return nil
+		}
+		return err
+	}
+	stateUpdate, err := s.StarkNetData.PendingStateUpdate(ctx)
+	if err != nil {
+		s.pending.Store((*Pending)(nil))
+		if errors.Is(err, starknetdata.ErrBlockNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// the pending block may belong to a different branch or may have been
+	// accepted in between the requests
+	if !block.ParentHash.Equal(head.Hash) || !stateUpdate.OldRoot.Equal(head.GlobalStateRoot) {
+		s.pending.Store((*Pending)(nil))
+		return nil
+	}
+
+	block.Number = head.Number + 1
+	s.pending.Store(&Pending{Block: block, StateUpdate: stateUpdate})
+	return nil
+}
+
+// refreshStatuses updates the statuses of the latest blocks, up to statusRefreshDepth blocks below
+// the head, which are not final yet. Blocks accepted on L1 or rejected are final.
+//
+// Blocks are accepted on L1 in order, so starting from the oldest block which is not final, blocks
+// are fetched again until one whose status did not change.
+func (s *Synchronizer) refreshStatuses(ctx context.Context) error {
+	height := s.Blockchain.Height()
+	if height == nil {
+		return nil
+	}
+
+	var number uint64
+	if *height >= statusRefreshDepth {
+		number = *height - statusRefreshDepth + 1
+	}
+	for ; number <= *height; number++ {
+		status, err := s.Blockchain.BlockStatus(number)
+		if err != nil {
+			return err
+		} else if status == core.StatusAcceptedOnL1 || status == core.StatusRejected {
+			continue
+		}
+
+		stored, err := s.Blockchain.BlockByNumber(number)
+		if err != nil {
+			return err
+		}
+		block, err := s.StarkNetData.BlockByNumber(ctx, number)
+		if err != nil {
+			return err
+		}
+		// the block may have been replaced by a reorg which is not synced yet, or its
+		// status may not be known to this version of Juno
+		if !block.Hash.Equal(stored.Hash) || block.Status == core.StatusUnknown || block.Status == status {
+			return nil
+		}
+
+		if err = s.Blockchain.SetBlockStatus(number, block.Status); err != nil {
+			return err
+		}
+		s.log.Infow("Updated block status", "number", number, "hash", stored.Hash.Text(16),
+			"status", block.Status)
+	}
+	return nil
+}
@@ -1,18 +1,20 @@
 package sync
 
 import (
-	_ "embed"
-	"encoding/json"
+	"context"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/NethermindEth/juno/blockchain"
-	"github.com/NethermindEth/juno/clients"
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/db"
-	"github.com/NethermindEth/juno/encoder"
-	"github.com/NethermindEth/juno/starknetdata/gateway"
+	"github.com/NethermindEth/juno/starknetdata"
+	"github.com/NethermindEth/juno/starknetdata/gateway/gatewaytest"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,39 +22,23 @@ import (
 func TestSyncBlocks(t *testing.T) {
 	testBlockchain := func(t *testing.T, testDB db.DB, fakeData *fakeStarkNetData) bool {
 		return assert.NoError(t, testDB.View(func(txn db.Transaction) error {
-			headBlockBinary, err := txn.Get(db.HeadBlock.Key())
+			headBinary, err := txn.Get(db.HeadBlock.Key())
 			if err != nil {
 				return err
 			}
 
-			headBlock := new(core.Block)
-			if err = encoder.Unmarshal(headBlockBinary, headBlock); err != nil {
-				return err
-			}
-
-			height := int(headBlock.Number)
+			height := int(binary.BigEndian.Uint64(headBinary))
 			for height >= 0 {
-				b, err := fakeData.BlockByNumber(uint64(height))
-				if err != nil {
-					return err
-				}
-
-				dbKey := blockchain.BlockDbKey{Number: b.Number, Hash: b.Hash}
-				key, err := dbKey.MarshalBinary()
+				b, err := fakeData.BlockByNumber(context.Background(), uint64(height))
 				if err != nil {
 					return err
 				}
 
-				blockBinary, err := txn.Get(key)
+				block, err := blockchain.NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger()).BlockByNumber(uint64(height))
 				if err != nil {
 					return err
 				}
 
-				block := new(core.Block)
-				if err = encoder.Unmarshal(blockBinary, block); err != nil {
-					return err
-				}
-
 				assert.Equal(t, b, block)
 				height--
 			}
@@ -61,87 +47,319 @@ func TestSyncBlocks(t *testing.T) {
 	}
 	t.Run("sync multiple blocks in an empty db", func(t *testing.T) {
 		testDB := db.NewTestDb()
-		bc := blockchain.NewBlockchain(testDB, utils.MAINNET)
-		fakeData := newFakeStarkNetData()
-		synchronizer := NewSynchronizer(bc, fakeData)
-		assert.Error(t, synchronizer.SyncBlocks())
+		bc := blockchain.NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
+		fakeData := newFakeStarkNetData(t)
+		synchronizer := NewSynchronizer(bc, fakeData, 1, 0, utils.NewNopZapLogger())
+		assert.Error(t, synchronizer.SyncBlocks(context.Background()))
+
+		testBlockchain(t, testDB, fakeData)
+	})
+	t.Run("sync multiple blocks concurrently in an empty db", func(t *testing.T) {
+		testDB := db.NewTestDb()
+		bc := blockchain.NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
+		fakeData := newFakeStarkNetData(t)
+		synchronizer := NewSynchronizer(bc, fakeData, 8, 0, utils.NewNopZapLogger())
+		assert.Error(t, synchronizer.SyncBlocks(context.Background()))
 
 		testBlockchain(t, testDB, fakeData)
+		assert.Equal(t, uint64(2), synchronizer.HighestBlockHeader().Number)
 	})
 	t.Run("sync multiple blocks in a non-empty db", func(t *testing.T) {
 		testDB := db.NewTestDb()
-		bc := blockchain.NewBlockchain(testDB, utils.MAINNET)
-		fakeData := newFakeStarkNetData()
-		b0, err := fakeData.BlockByNumber(0)
+		bc := blockchain.NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
+		fakeData := newFakeStarkNetData(t)
+		b0, err := fakeData.BlockByNumber(context.Background(), 0)
 		assert.NoError(t, err)
-		s0, err := fakeData.StateUpdate(0)
+		s0, err := fakeData.StateUpdate(context.Background(), 0)
 		assert.NoError(t, err)
 		assert.NoError(t, bc.Store(b0, s0))
 
-		synchronizer := NewSynchronizer(bc, fakeData)
-		assert.Error(t, synchronizer.SyncBlocks())
+		synchronizer := NewSynchronizer(bc, fakeData, 4, 0, utils.NewNopZapLogger())
+		assert.Error(t, synchronizer.SyncBlocks(context.Background()))
+
+		testBlockchain(t, testDB, fakeData)
+	})
+	t.Run("revert head block on reorg", func(t *testing.T) {
+		testDB := db.NewTestDb()
+		bc := blockchain.NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
+		fakeData := newFakeStarkNetData(t)
+		for i := uint64(0); i < 2; i++ {
+			b, err := fakeData.BlockByNumber(context.Background(), i)
+			assert.NoError(t, err)
+			s, err := fakeData.StateUpdate(context.Background(), i)
+			assert.NoError(t, err)
+			assert.NoError(t, bc.Store(b, s))
+		}
+
+		// the first time block 2 is fetched its parent is not the stored block 1
+		reorgData := &reorgStarkNetData{fakeStarkNetData: fakeData, reorgBlock: 2}
+		synchronizer := NewSynchronizer(bc, reorgData, 2, 0, utils.NewNopZapLogger())
+		assert.Error(t, synchronizer.SyncBlocks(context.Background()))
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&reorgData.reorged))
+
+		testBlockchain(t, testDB, fakeData)
+	})
+	t.Run("keep syncing when StarkNet data is unavailable", func(t *testing.T) {
+		testDB := db.NewTestDb()
+		bc := blockchain.NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
+		fakeData := newFakeStarkNetData(t)
+
+		// block 1 cannot be fetched the first 3 times it is requested
+		unavailableData := &unavailableStarkNetData{fakeStarkNetData: fakeData, unavailableBlock: 1, outages: 3}
+		synchronizer := NewSynchronizer(bc, unavailableData, 2, 0, utils.NewNopZapLogger())
+		err := synchronizer.SyncBlocks(context.Background())
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, starknetdata.ErrUnavailable)
+		assert.Greater(t, int32(0), atomic.LoadInt32(&unavailableData.outages))
 
 		testBlockchain(t, testDB, fakeData)
 	})
 }
 
+func TestPollHead(t *testing.T) {
+	testDB := db.NewTestDb()
+	bc := blockchain.NewBlockchain(testDB, utils.MAINNET, utils.NewNopZapLogger())
+	tipData := &tipStarkNetData{fakeStarkNetData: newFakeStarkNetData(t), available: 1}
+	synchronizer := NewSynchronizer(bc, tipData, 4, time.Millisecond, utils.NewNopZapLogger())
+
+	done := make(chan error)
+	go func() {
+		done <- synchronizer.Run()
+	}()
+
+	waitForHeight := func(height uint64) {
+		for h := bc.Height(); h == nil || *h < height; h = bc.Height() {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	waitForHeight(0)
+	// the next block is served as the pending block
+	for synchronizer.Pending() == nil {
+		time.Sleep(time.Millisecond)
+	}
+	pending := synchronizer.Pending()
+	block1, err := tipData.fakeStarkNetData.BlockByNumber(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), pending.Block.Number)
+	assert.Nil(t, pending.Block.Hash)
+	assert.Equal(t, block1.ParentHash, pending.Block.ParentHash)
+	assert.Equal(t, len(block1.Transactions), len(pending.Block.Transactions))
+
+	// new blocks are picked up once they are published
+	atomic.StoreUint64(&tipData.available, 3)
+	waitForHeight(2)
+	// there are no more blocks to serve as pending
+	for synchronizer.Pending() != nil {
+		time.Sleep(time.Millisecond)
+	}
+
+	assert.NoError(t, synchronizer.Shutdown())
+	assert.NoError(t, <-done)
+}
+
+// tipStarkNetData only serves the first available blocks of fakeStarkNetData,
+// the block following them is served as the pending block.
+type tipStarkNetData struct {
+	*fakeStarkNetData
+	available uint64
+}
+
+func (d *tipStarkNetData) BlockByNumber(ctx context.Context, blockNumber uint64) (*core.Block, error) {
+	if blockNumber >= atomic.LoadUint64(&d.available) {
+		return nil, starknetdata.ErrBlockNotFound
+	}
+	return d.fakeStarkNetData.BlockByNumber(ctx, blockNumber)
+}
+
+func (d *tipStarkNetData) StateUpdate(ctx context.Context, blockNumber uint64) (*core.StateUpdate, error) {
+	if blockNumber >= atomic.LoadUint64(&d.available) {
+		return nil, starknetdata.ErrBlockNotFound
+	}
+	return d.fakeStarkNetData.StateUpdate(ctx, blockNumber)
+}
+
+func (d *tipStarkNetData) LatestBlock(ctx context.Context) (*core.Block, error) {
+	available := atomic.LoadUint64(&d.available)
+	if available == 0 {
+		return nil, starknetdata.ErrBlockNotFound
+	}
+	return d.fakeStarkNetData.BlockByNumber(ctx, available-1)
+}
+
+func (d *tipStarkNetData) PendingBlock(ctx context.Context) (*core.Block, error) {
+	b, err := d.fakeStarkNetData.BlockByNumber(ctx, atomic.LoadUint64(&d.available))
+	if err != nil {
+		return nil, starknetdata.ErrBlockNotFound
+	}
+
+	pending := *b
+	pending.Hash, pending.Number, pending.GlobalStateRoot = nil, 0, nil
+	return &pending, nil
+}
+
+func (d *tipStarkNetData) PendingStateUpdate(ctx context.Context) (*core.StateUpdate, error) {
+	u, err := d.fakeStarkNetData.StateUpdate(ctx, atomic.LoadUint64(&d.available))
+	if err != nil {
+		return nil, starknetdata.ErrBlockNotFound
+	}
+
+	pending := *u
+	pending.BlockHash, pending.NewRoot = nil, nil
+	return &pending, nil
+}
+
+func TestRefreshStatuses(t *testing.T) {
+	bc := blockchain.NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+	fakeData := newFakeStarkNetData(t)
+	for i := uint64(0); i < 3; i++ {
+		b, err := fakeData.BlockByNumber(context.Background(), i)
+		assert.NoError(t, err)
+		s, err := fakeData.StateUpdate(context.Background(), i)
+		assert.NoError(t, err)
+
+		stored := *b
+		stored.Status = core.StatusAcceptedOnL2
+		assert.NoError(t, bc.Store(&stored, s))
+	}
+
+	statusData := &statusStarkNetData{
+		fakeStarkNetData: fakeData,
+		statuses: map[uint64]core.BlockStatus{
+			0: core.StatusAcceptedOnL1,
+			1: core.StatusAcceptedOnL2,
+			2: core.StatusAcceptedOnL1,
+		},
+	}
+	synchronizer := NewSynchronizer(bc, statusData, 1, 0, utils.NewNopZapLogger())
+	assertStatuses := func(t *testing.T, want ...core.BlockStatus) {
+		for number, status := range want {
+			got, err := bc.BlockStatus(uint64(number))
+			assert.NoError(t, err)
+			assert.Equal(t, status, got, "block %d", number)
+		}
+	}
+
+	// the refresh stops at block 1, which is still accepted on L2
+	assert.NoError(t, synchronizer.refreshStatuses(context.Background()))
+	assert.Equal(t, []uint64{0, 1}, statusData.fetched)
+	assertStatuses(t, core.StatusAcceptedOnL1, core.StatusAcceptedOnL2, core.StatusAcceptedOnL2)
+
+	t.Run("final blocks are not fetched again", func(t *testing.T) {
+		statusData.fetched = nil
+		statusData.statuses[0] = core.StatusRejected
+		statusData.statuses[1] = core.StatusAcceptedOnL1
+		statusData.statuses[2] = core.StatusUnknown
+
+		assert.NoError(t, synchronizer.refreshStatuses(context.Background()))
+		assert.Equal(t, []uint64{1, 2}, statusData.fetched)
+		assertStatuses(t, core.StatusAcceptedOnL1, core.StatusAcceptedOnL1, core.StatusAcceptedOnL2)
+	})
+}
+
+// statusStarkNetData serves the blocks of fakeStarkNetData with the given statuses and
+// records the numbers of the blocks fetched.
+type statusStarkNetData struct {
+	*fakeStarkNetData
+	statuses map[uint64]core.BlockStatus
+	fetched  []uint64
+}
+
+func (d *statusStarkNetData) BlockByNumber(ctx context.Context, blockNumber uint64) (*core.Block, error) {
+	b, err := d.fakeStarkNetData.BlockByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	d.fetched = append(d.fetched, blockNumber)
+
+	block := *b
+	block.Status = d.statuses[blockNumber]
+	return &block, nil
+}
+
+func TestShutdown(t *testing.T) {
+	bc := blockchain.NewBlockchain(db.NewTestDb(), utils.MAINNET, utils.NewNopZapLogger())
+	synchronizer := NewSynchronizer(bc, blockingStarkNetData{newFakeStarkNetData(t)}, 2, 0, utils.NewNopZapLogger())
+
+	done := make(chan error)
+	go func() {
+		done <- synchronizer.Run()
+	}()
+	for synchronizer.HighestBlockHeader() == nil {
+		time.Sleep(time.Millisecond)
+	}
+	// the latest block is known although no block could be stored
+	assert.Equal(t, uint64(2), synchronizer.HighestBlockHeader().Number)
+
+	assert.NoError(t, synchronizer.Shutdown())
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("synchronizer did not stop")
+	}
+}
+
+// blockingStarkNetData blocks fetching state updates until ctx is cancelled.
+type blockingStarkNetData struct {
+	*fakeStarkNetData
+}
+
+func (b blockingStarkNetData) StateUpdate(ctx context.Context, _ uint64) (*core.StateUpdate, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// reorgStarkNetData serves a block with an unknown parent the first
+// time reorgBlock is requested.
+type reorgStarkNetData struct {
+	*fakeStarkNetData
+	reorgBlock uint64
+	reorged    uint32
+}
+
+func (r *reorgStarkNetData) BlockByNumber(ctx context.Context, blockNumber uint64) (*core.Block, error) {
+	b, err := r.fakeStarkNetData.BlockByNumber(ctx, blockNumber)
+	if err != nil || blockNumber != r.reorgBlock || !atomic.CompareAndSwapUint32(&r.reorged, 0, 1) {
+		return b, err
+	}
+
+	reorgBlock := *b
+	reorgBlock.ParentHash = new(felt.Felt).SetUint64(44)
+	return &reorgBlock, nil
+}
+
+// unavailableStarkNetData fails with ErrUnavailable the first outages
+// times unavailableBlock is requested.
+type unavailableStarkNetData struct {
+	*fakeStarkNetData
+	unavailableBlock uint64
+	outages          int32
+}
+
+func (u *unavailableStarkNetData) BlockByNumber(ctx context.Context, blockNumber uint64) (*core.Block, error) {
+	if blockNumber == u.unavailableBlock && atomic.AddInt32(&u.outages, -1) >= 0 {
+		return nil, fmt.Errorf("%w: 503 Service Unavailable", starknetdata.ErrUnavailable)
+	}
+	return u.fakeStarkNetData.BlockByNumber(ctx, blockNumber)
+}
+
 type fakeStarkNetData struct {
 	blocks      map[uint64]*core.Block
 	stateUpdate map[uint64]*core.StateUpdate
 }
 
-func newFakeStarkNetData() *fakeStarkNetData {
-	blocksM, stateUpdateM := populateBlocksAndStateUpdate()
-	return &fakeStarkNetData{blocksM, stateUpdateM}
-}
-
-// As mentioned here: https://dariodip.medium.com/go-embed-unleashed-1eab8b4b1ba6.
-// It is possible that file are excluded from when go build is run. This needs to be investigated.
-var (
-	//go:embed testdata/mainnet_block_0.json
-	mainnetBlock0 []byte
-	//go:embed testdata/mainnet_block_1.json
-	mainnetBlock1 []byte
-	//go:embed testdata/mainnet_block_2.json
-	mainnetBlock2 []byte
-	//go:embed testdata/mainnet_state_update_0.json
-	mainnetStateUpdate0 []byte
-	//go:embed testdata/mainnet_state_update_1.json
-	mainnetStateUpdate1 []byte
-	//go:embed testdata/mainnet_state_update_2.json
-	mainnetStateUpdate2 []byte
-)
-
-func populateBlocksAndStateUpdate() (map[uint64]*core.Block, map[uint64]*core.StateUpdate) {
-	numOfBlocks := 3
-	bm := make(map[uint64]*core.Block, 3)
-	sm := make(map[uint64]*core.StateUpdate, 3)
-	rawBlocks := [][]byte{mainnetBlock0, mainnetBlock1, mainnetBlock2}
-	rawStateUpdate := [][]byte{mainnetStateUpdate0, mainnetStateUpdate1, mainnetStateUpdate2}
-
-	for i := 0; i < numOfBlocks; i++ {
-		clientBlock, clientStateUpdate := new(clients.Block), new(clients.StateUpdate)
-		if err := json.Unmarshal(rawBlocks[i], clientBlock); err != nil {
-			panic(err)
-		}
-		if err := json.Unmarshal(rawStateUpdate[i], clientStateUpdate); err != nil {
-			panic(err)
-		}
-		b, err := gateway.AdaptBlock(clientBlock)
-		if err != nil {
-			panic(err)
-		}
-		bm[uint64(i)] = b
-		su, err := gateway.AdaptStateUpdate(clientStateUpdate)
-		if err != nil {
-			panic(err)
-		}
-		sm[uint64(i)] = su
+func newFakeStarkNetData(t *testing.T) *fakeStarkNetData {
+	data := &fakeStarkNetData{
+		blocks:      make(map[uint64]*core.Block),
+		stateUpdate: make(map[uint64]*core.StateUpdate),
+	}
+	for number := uint64(0); number < 3; number++ {
+		data.blocks[number], data.stateUpdate[number] = gatewaytest.MainnetBlock(t, number)
 	}
-	return bm, sm
+	return data
 }
 
-func (f *fakeStarkNetData) BlockByNumber(blockNumber uint64) (*core.Block, error) {
+func (f *fakeStarkNetData) BlockByNumber(_ context.Context, blockNumber uint64) (*core.Block, error) {
 	b := f.blocks[blockNumber]
 	if b == nil {
 		return nil, errors.New("unknown block")
@@ -149,7 +367,7 @@ func (f *fakeStarkNetData) BlockByNumber(blockNumber uint64) (*core.Block, error
 	return b, nil
 }
 
-func (f *fakeStarkNetData) StateUpdate(blockNumber uint64) (*core.StateUpdate, error) {
+func (f *fakeStarkNetData) StateUpdate(_ context.Context, blockNumber uint64) (*core.StateUpdate, error) {
 	u := f.stateUpdate[blockNumber]
 	if u == nil {
 		return nil, errors.New("unknown state update")
@@ -157,10 +375,22 @@ func (f *fakeStarkNetData) StateUpdate(blockNumber uint64) (*core.StateUpdate, e
 	return u, nil
 }
 
-func (f *fakeStarkNetData) Transaction(_ *felt.Felt) (core.Transaction, error) {
+func (f *fakeStarkNetData) LatestBlock(ctx context.Context) (*core.Block, error) {
+	return f.BlockByNumber(ctx, uint64(len(f.blocks)-1))
+}
+
+func (f *fakeStarkNetData) PendingBlock(_ context.Context) (*core.Block, error) {
+	return nil, starknetdata.ErrBlockNotFound
+}
+
+func (f *fakeStarkNetData) PendingStateUpdate(_ context.Context) (*core.StateUpdate, error) {
+	return nil, starknetdata.ErrBlockNotFound
+}
+
+func (f *fakeStarkNetData) Transaction(_ context.Context, _ *felt.Felt) (core.Transaction, error) {
 	return nil, nil
 }
 
-func (f *fakeStarkNetData) Class(_ *felt.Felt) (*core.Class, error) {
+func (f *fakeStarkNetData) Class(_ context.Context, _ *felt.Felt) (*core.Class, error) {
 	return nil, nil
 }
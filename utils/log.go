@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logger is a leveled logger, the context of each message is given as alternating
+// keys and values, e.g. log.Infow("Stored block", "number", 42, "hash", hash).
+type Logger interface {
+	Debugw(msg string, keysAndValues ...any)
+	Infow(msg string, keysAndValues ...any)
+	Warnw(msg string, keysAndValues ...any)
+	Errorw(msg string, keysAndValues ...any)
+}
+
+var _ Logger = (*zap.SugaredLogger)(nil)
+
+// Log formats supported by [NewZapLogger].
+const (
+	LogFormatConsole = "console"
+	LogFormatJson    = "json"
+)
+
+// NewZapLogger creates a Logger writing messages of the given verbosity and above to stderr
+// in the given format, console by default. The verbosity is one of debug, info, warn, error, dpanic, panic and fatal.
+func NewZapLogger(verbosity, format string) (Logger, error) {
+	level, err := zapcore.ParseLevel(verbosity)
+	if err != nil {
+		return nil, err
+	}
+
+	config := zap.NewProductionConfig()
+	config.Level = zap.NewAtomicLevelAt(level)
+	config.Sampling = nil
+	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	switch format {
+	case LogFormatConsole, "":
+		config.Encoding = LogFormatConsole
+		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	case LogFormatJson:
+		config.Encoding = format
+	default:
+		return nil, errors.New("unknown log format: " + format)
+	}
+
+	logger, err := config.Build()
+	if err != nil {
+		return nil, err
+	}
+	return logger.Sugar(), nil
+}
+
+// NewNopZapLogger returns a Logger discarding all messages.
+func NewNopZapLogger() Logger {
+	return zap.NewNop().Sugar()
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewZapLogger(t *testing.T) {
+	for _, format := range []string{LogFormatConsole, LogFormatJson} {
+		for _, verbosity := range []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"} {
+			t.Run(format+" "+verbosity, func(t *testing.T) {
+				logger, err := NewZapLogger(verbosity, format)
+				require.NoError(t, err)
+
+				level, err := zapcore.ParseLevel(verbosity)
+				require.NoError(t, err)
+				core := logger.(*zap.SugaredLogger).Desugar().Core()
+				assert.True(t, core.Enabled(level))
+				assert.False(t, core.Enabled(level-1))
+			})
+		}
+	}
+
+	t.Run("unknown verbosity", func(t *testing.T) {
+		_, err := NewZapLogger("verbose", LogFormatConsole)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := NewZapLogger("info", "xml")
+		assert.EqualError(t, err, "unknown log format: xml")
+	})
+}
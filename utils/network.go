@@ -1,6 +1,9 @@
 package utils
 
-import "github.com/NethermindEth/juno/core/felt"
+import (
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type Network uint8
 
@@ -55,3 +58,20 @@ func (n Network) ChainId() *felt.Felt {
 		return nil
 	}
 }
+
+// CoreContractAddress returns the address of the StarkNet core contract on L1,
+// which verifies and records the state updates of the network.
+func (n Network) CoreContractAddress() common.Address {
+	switch n {
+	case GOERLI:
+		return common.HexToAddress("0xde29d060D45901Fb19ED6C6e959EB22d8626708e")
+	case MAINNET:
+		return common.HexToAddress("0xc662c410C0ECf747543f5bA90660f6ABeBD9C8c4")
+	case GOERLI2:
+		return common.HexToAddress("0xa4eD3aD27c294565cB0DCc993BDdCC75432D498c")
+	case INTEGRATION:
+		return common.HexToAddress("0xd5c325D183C592C94998000C5e0EED9e6655c020")
+	default:
+		return common.Address{}
+	}
+}